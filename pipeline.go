@@ -0,0 +1,117 @@
+package prose
+
+import "github.com/jdkato/prose/tokenize"
+
+// A Segmenter splits text into sentences.
+//
+// Any of the sentence tokenizers in the tokenize package (e.g.,
+// PragmaticSegmenter or PunktSentenceTokenizer) can be used as a Segmenter.
+type Segmenter interface {
+	Tokenize(text string) []string
+}
+
+// A Tokenizer splits a sentence into words.
+//
+// Any of the word tokenizers in the tokenize package (e.g.,
+// TreebankWordTokenizer) can be used as a Tokenizer.
+type Tokenizer interface {
+	Tokenize(text string) []string
+}
+
+// A Tagger assigns a part-of-speech tag to each word of a sentence.
+//
+// A tag.PerceptronTagger can be used as a Tagger by way of its PipelineTagger
+// method. (This package doesn't import the tag package, so that using a
+// Pipeline without a Tagger doesn't require its built-in model.)
+type Tagger interface {
+	Tag(words []string) []Token
+}
+
+// A Token is a word of a Sentence and its part-of-speech tag (if any).
+type Token struct {
+	Text string
+	Tag  string
+}
+
+// A Sentence is a single sentence produced by a Pipeline.
+type Sentence struct {
+	Text   string  // the actual text
+	Tokens []Token // the sentence's words (and their tags, if any)
+}
+
+// A Pipeline runs its stages, in order, over a piece of text: segmentation,
+// then tokenization, then tagging.
+//
+// Each stage is optional. Without a Segmenter, the entire text is treated as a
+// single sentence; without a Tokenizer, no words (and therefore no tags) are
+// produced; and without a Tagger, each word's Tag is empty.
+type Pipeline struct {
+	Segmenter Segmenter
+	Tokenizer Tokenizer
+	Tagger    Tagger
+}
+
+// A PipelineOption customizes a Pipeline created by NewPipeline.
+type PipelineOption func(p *Pipeline)
+
+// WithSegmenter replaces the default Segmenter (a PunktSentenceTokenizer). A
+// nil Segmenter disables segmentation.
+func WithSegmenter(s Segmenter) PipelineOption {
+	return func(p *Pipeline) {
+		p.Segmenter = s
+	}
+}
+
+// WithTokenizer replaces the default Tokenizer (a TreebankWordTokenizer). A
+// nil Tokenizer disables tokenization.
+func WithTokenizer(t Tokenizer) PipelineOption {
+	return func(p *Pipeline) {
+		p.Tokenizer = t
+	}
+}
+
+// WithTagger adds a tagging stage, such as a tag.PerceptronTagger's
+// PipelineTagger, to the Pipeline. There's no Tagger by default since loading a model is expensive.
+func WithTagger(t Tagger) PipelineOption {
+	return func(p *Pipeline) {
+		p.Tagger = t
+	}
+}
+
+// NewPipeline creates a Pipeline that segments text with a
+// PunktSentenceTokenizer and tokenizes each sentence with a
+// TreebankWordTokenizer, unless configured otherwise by opts.
+func NewPipeline(opts ...PipelineOption) *Pipeline {
+	p := Pipeline{
+		Segmenter: tokenize.NewPunktSentenceTokenizer(),
+		Tokenizer: tokenize.NewTreebankWordTokenizer()}
+	for _, applyOpt := range opts {
+		applyOpt(&p)
+	}
+	return &p
+}
+
+// Run applies each of the Pipeline's stages to text.
+func (p *Pipeline) Run(text string) []Sentence {
+	sents := []string{text}
+	if p.Segmenter != nil {
+		sents = p.Segmenter.Tokenize(text)
+	}
+
+	results := []Sentence{}
+	for _, s := range sents {
+		sent := Sentence{Text: s}
+		if p.Tokenizer != nil {
+			words := p.Tokenizer.Tokenize(s)
+			if p.Tagger != nil {
+				sent.Tokens = p.Tagger.Tag(words)
+			} else {
+				for _, word := range words {
+					sent.Tokens = append(sent.Tokens, Token{Text: word})
+				}
+			}
+		}
+		results = append(results, sent)
+	}
+	return results
+}
@@ -0,0 +1,13 @@
+package prose
+
+import "github.com/jdkato/prose/tokenize"
+
+// TokenizeWords splits text into a flat slice of words and punctuation.
+//
+// This is the simplest way to get started with prose: text is segmented into
+// sentences, each of which is then split into words by the
+// TreebankWordTokenizer (see tokenize.TextToWords). Use the tokenize package
+// directly for more control over either step.
+func TokenizeWords(text string) []string {
+	return tokenize.TextToWords(text)
+}
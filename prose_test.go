@@ -0,0 +1,37 @@
+package prose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jdkato/prose/tokenize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeWords(t *testing.T) {
+	assert.Equal(t,
+		[]string{"They", "'ll", "save", "and", "invest", "more", "."},
+		TokenizeWords("They'll save and invest more."))
+}
+
+type fieldsTokenizer struct{}
+
+func (t fieldsTokenizer) Tokenize(text string) []string {
+	return strings.Fields(text)
+}
+
+func TestCustomPipeline(t *testing.T) {
+	seg, err := tokenize.NewPragmaticSegmenter("en")
+	assert.NoError(t, err)
+
+	p := Pipeline{Segmenter: seg, Tokenizer: fieldsTokenizer{}}
+	assert.Equal(t, []Sentence{
+		{Text: "Hello World.", Tokens: []Token{
+			{Text: "Hello"}, {Text: "World."}}},
+		{Text: "My name is Jonas.", Tokens: []Token{
+			{Text: "My"}, {Text: "name"}, {Text: "is"}, {Text: "Jonas."}}},
+	}, p.Run("Hello World. My name is Jonas."))
+
+	p = Pipeline{Tokenizer: fieldsTokenizer{}}
+	assert.Len(t, p.Run("Hello World. My name is Jonas."), 1)
+}
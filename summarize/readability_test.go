@@ -3,9 +3,11 @@ package summarize
 import (
 	"encoding/json"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jdkato/prose/internal/util"
+	"github.com/jdkato/prose/tokenize"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,6 +32,56 @@ func TestReadability(t *testing.T) {
 	}
 }
 
+func TestSyllableCounter(t *testing.T) {
+	text := "El perro come la comida. La casa es muy grande."
+
+	english := NewDocument(text)
+
+	spanish := Document{
+		Content:           text,
+		WordTokenizer:     tokenize.NewWordBoundaryTokenizer(),
+		SentenceTokenizer: tokenize.NewPunktSentenceTokenizer(),
+		SyllableCounter: func(word string) int {
+			// A naive vowel-group count, which is a decent approximation for
+			// Spanish.
+			count := 0
+			prev := false
+			for _, r := range strings.ToLower(word) {
+				vowel := strings.ContainsRune("aeiouáéíóú", r)
+				if vowel && !prev {
+					count++
+				}
+				prev = vowel
+			}
+			return count
+		}}
+	spanish.Initialize()
+
+	assert.Equal(t, 16.0, spanish.NumSyllables)
+	assert.NotEqual(t, english.NumSyllables, spanish.NumSyllables)
+	assert.NotEqual(t, english.FleschReadingEase(), spanish.FleschReadingEase())
+}
+
+func TestSyllableDictionary(t *testing.T) {
+	text := "The fire was hot. Every orange cat is beautiful."
+	dict := map[string]int{"fire": 1, "every": 2, "orange": 2, "Beautiful": 3}
+
+	heuristic := NewDocument(text)
+	exact := NewDocument(text, WithSyllableDictionary(dict))
+
+	// Words are matched regardless of case.
+	counts := []int{}
+	for _, sent := range exact.Sentences {
+		for _, word := range sent.Words {
+			counts = append(counts, word.Syllables)
+		}
+	}
+	assert.Equal(t, []int{1, 1, 1, 1, 2, 2, 1, 1, 3}, counts)
+	assert.Equal(t, 13.0, exact.NumSyllables)
+	assert.Equal(t, 14.0, heuristic.NumSyllables)
+	assert.True(t, check(80.07, exact.FleschReadingEase()))
+}
+
 func BenchmarkReadability(b *testing.B) {
 	in := util.ReadDataFile(filepath.Join(testdata, "sherlock.txt"))
 
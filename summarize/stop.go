@@ -1,5 +1,44 @@
 package summarize
 
+import "strings"
+
+// stopWordSets maps a language code to its set of (lowercase) stop words.
+var stopWordSets = map[string]map[string]struct{}{
+	"en": toSet(stopWords),
+}
+
+// Stopwords returns the set of stop words for the given language (e.g., "en").
+//
+// All keys are lowercase, so callers should normalize words with
+// strings.ToLower before looking them up. A new map is returned on each call,
+// which means it's safe to extend with domain-specific words. If lang isn't
+// supported, Stopwords returns nil.
+func Stopwords(lang string) map[string]struct{} {
+	set, found := stopWordSets[strings.ToLower(lang)]
+	if !found {
+		return nil
+	}
+	words := make(map[string]struct{}, len(set))
+	for word := range set {
+		words[word] = struct{}{}
+	}
+	return words
+}
+
+// isStopWord reports whether word, in any case, is an English stop word.
+func isStopWord(word string) bool {
+	_, found := stopWordSets["en"][strings.ToLower(word)]
+	return found
+}
+
+func toSet(words []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(words))
+	for _, word := range words {
+		set[word] = struct{}{}
+	}
+	return set
+}
+
 var stopWords = []string{
 	"a",
 	"about",
@@ -0,0 +1,33 @@
+package summarize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopwords(t *testing.T) {
+	words := Stopwords("en")
+	for _, word := range []string{"the", "and", "a", "of", "yourselves"} {
+		_, found := words[word]
+		assert.True(t, found, word)
+	}
+
+	_, found := words["prose"]
+	assert.False(t, found)
+
+	// Users can extend their copy without affecting the package's list.
+	words["prose"] = struct{}{}
+	_, found = Stopwords("EN")["prose"]
+	assert.False(t, found)
+
+	assert.True(t, isStopWord("The"))
+	assert.Len(t, Stopwords("xx"), 0)
+}
+
+func BenchmarkStopwordsLookup(b *testing.B) {
+	words := Stopwords("en")
+	for n := 0; n < b.N; n++ {
+		_ = words["yourselves"]
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/jdkato/prose"
 	"github.com/jdkato/prose/internal/util"
 	"github.com/jdkato/prose/tokenize"
 
@@ -44,6 +45,9 @@ type RankedParagraph struct {
 //
 //    d := Document{Content: ..., WordTokenizer: ..., SentenceTokenizer: ...}
 //    d.Initialize()
+//
+// Similarly, syllables are counted using the English heuristic implemented by
+// Syllables unless SyllableCounter is set (e.g., to support another language).
 type Document struct {
 	Content         string         // Actual text
 	NumCharacters   float64        // Number of Characters
@@ -58,6 +62,7 @@ type Document struct {
 
 	SentenceTokenizer tokenize.ProseTokenizer
 	WordTokenizer     tokenize.ProseTokenizer
+	SyllableCounter   func(word string) int
 }
 
 // An Assessment provides comprehensive access to a Document's metrics.
@@ -78,17 +83,48 @@ type Assessment struct {
 	ReadingEase float64
 }
 
+// A DocumentOption customizes a Document created by NewDocument.
+type DocumentOption func(d *Document)
+
+// WithSyllableDictionary uses the exact syllable counts in dict, whose words
+// are matched regardless of case, rather than estimating them. Words that
+// aren't in dict are still counted by the Document's SyllableCounter (or
+// Syllables, if there isn't one).
+func WithSyllableDictionary(dict map[string]int) DocumentOption {
+	return func(d *Document) {
+		exact := make(map[string]int, len(dict))
+		for word, syllables := range dict {
+			exact[strings.ToLower(word)] = syllables
+		}
+		fallback := d.SyllableCounter
+		if fallback == nil {
+			fallback = Syllables
+		}
+		d.SyllableCounter = func(word string) int {
+			if syllables, found := exact[strings.ToLower(word)]; found {
+				return syllables
+			}
+			return fallback(word)
+		}
+	}
+}
+
 // NewDocument is a Document constructor that takes a string as an argument. It
 // then calculates the data necessary for computing readability and usage
 // statistics.
 //
 // This is a convenience wrapper around the Document initialization process
 // that defaults to using a WordBoundaryTokenizer and a PunktSentenceTokenizer
-// as its word and sentence tokenizers, respectively.
-func NewDocument(text string) *Document {
-	wTok := tokenize.NewWordBoundaryTokenizer()
-	sTok := tokenize.NewPunktSentenceTokenizer()
-	doc := Document{Content: text, WordTokenizer: wTok, SentenceTokenizer: sTok}
+// as its word and sentence tokenizers, respectively, unless configured
+// otherwise by opts.
+func NewDocument(text string, opts ...DocumentOption) *Document {
+	p := prose.NewPipeline(
+		prose.WithTokenizer(tokenize.NewWordBoundaryTokenizer()))
+	doc := Document{
+		Content: text, WordTokenizer: p.Tokenizer, SentenceTokenizer: p.Segmenter}
+	for _, applyOpt := range opts {
+		applyOpt(&doc)
+	}
 	doc.Initialize()
 	return &doc
 }
@@ -96,14 +132,22 @@ func NewDocument(text string) *Document {
 // Initialize calculates the data necessary for computing readability and usage
 // statistics.
 func (d *Document) Initialize() {
+	countSyllables := d.SyllableCounter
+	if countSyllables == nil {
+		countSyllables = Syllables
+	}
+
+	p := prose.Pipeline{
+		Segmenter: d.SentenceTokenizer, Tokenizer: d.WordTokenizer}
+
 	d.WordFrequency = make(map[string]int)
 	for i, paragraph := range strings.Split(d.Content, "\n\n") {
-		for _, s := range d.SentenceTokenizer.Tokenize(paragraph) {
+		for _, s := range p.Run(paragraph) {
 			wordCount := d.NumWords
 			d.NumSentences++
 			words := []Word{}
-			for _, word := range d.WordTokenizer.Tokenize(s) {
-				word = strings.TrimSpace(word)
+			for _, tok := range s.Tokens {
+				word := strings.TrimSpace(tok.Text)
 				if len(word) == 0 {
 					continue
 				}
@@ -113,7 +157,7 @@ func (d *Document) Initialize() {
 				} else {
 					d.WordFrequency[word] = 1
 				}
-				syllables := Syllables(word)
+				syllables := countSyllables(word)
 				words = append(words, Word{Text: word, Syllables: syllables})
 				d.NumSyllables += float64(syllables)
 				if syllables > 2 {
@@ -125,7 +169,7 @@ func (d *Document) Initialize() {
 				d.NumWords++
 			}
 			d.Sentences = append(d.Sentences, Sentence{
-				Text:      strings.TrimSpace(s),
+				Text:      strings.TrimSpace(s.Text),
 				Length:    int(d.NumWords - wordCount),
 				Words:     words,
 				Paragraph: i})
@@ -197,6 +241,24 @@ func (d *Document) Summary(n int) []RankedParagraph {
 	return rankings
 }
 
+// Abstract returns the Document's first maxSentences sentences joined by a
+// space, which is useful as a short preview of its content.
+//
+// Sentences are never truncated, but each one is trimmed and any paragraph
+// breaks between them are replaced by the joining space. So, if the Document
+// has fewer than maxSentences sentences, all of them are returned this way
+// rather than its original text.
+func (d *Document) Abstract(maxSentences int) string {
+	sents := []string{}
+	for i, s := range d.Sentences {
+		if i >= maxSentences {
+			break
+		}
+		sents = append(sents, s.Text)
+	}
+	return strings.Join(sents, " ")
+}
+
 type byRank []RankedParagraph
 
 func (s byRank) Len() int           { return len(s) }
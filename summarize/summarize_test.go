@@ -62,3 +62,16 @@ func TestSummarize(t *testing.T) {
 	}
 	fmt.Print(text)
 }
+
+func TestAbstract(t *testing.T) {
+	d := NewDocument("Go is an open-source language. It is simple. It is fast.")
+	assert.Equal(t, "", d.Abstract(0))
+	assert.Equal(t, "Go is an open-source language.", d.Abstract(1))
+	assert.Equal(t, "Go is an open-source language. It is simple.", d.Abstract(2))
+	assert.Equal(t, d.Content, d.Abstract(5))
+
+	d = NewDocument("Go is fast.  It is simple.\n\nIt has a mascot.")
+	assert.Equal(t, "Go is fast. It is simple.", d.Abstract(2))
+	assert.Equal(t,
+		"Go is fast. It is simple. It has a mascot.", d.Abstract(5))
+}
@@ -2,8 +2,8 @@ package summarize
 
 import (
 	"strings"
+	"time"
 
-	"github.com/jdkato/prose/internal/util"
 	"github.com/montanaflynn/stats"
 )
 
@@ -26,7 +26,7 @@ func (d *Document) Keywords() map[string]int {
 	scores := map[string]int{}
 	for word, freq := range d.WordFrequency {
 		normalized := strings.ToLower(word)
-		if util.StringInSlice(normalized, stopWords) {
+		if isStopWord(normalized) {
 			continue
 		}
 		if _, found := scores[normalized]; found {
@@ -43,3 +43,17 @@ func (d *Document) MeanWordLength() float64 {
 	val, _ := stats.Round(d.NumCharacters/d.NumWords, 3)
 	return val
 }
+
+// defaultWordsPerMinute is the reading speed assumed by ReadingTime when none
+// is given.
+const defaultWordsPerMinute = 200
+
+// ReadingTime returns the estimated time it takes to read the Document at the
+// given number of words per minute. A wpm less than or equal to 0 uses the
+// default of 200.
+func (d *Document) ReadingTime(wpm float64) time.Duration {
+	if wpm <= 0 {
+		wpm = defaultWordsPerMinute
+	}
+	return time.Duration(d.NumWords / wpm * float64(time.Minute))
+}
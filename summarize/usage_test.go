@@ -1,7 +1,9 @@
 package summarize
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -22,3 +24,11 @@ func TestUsage(t *testing.T) {
 	assert.Equal(t, dmap, d.WordDensity())
 	assert.Equal(t, 5.163, d.MeanWordLength())
 }
+
+func TestReadingTime(t *testing.T) {
+	d := NewDocument(strings.Repeat("Vale is a linter. ", 100))
+	assert.Equal(t, 400.0, d.NumWords)
+	assert.Equal(t, 2*time.Minute, d.ReadingTime(200))
+	assert.Equal(t, 2*time.Minute, d.ReadingTime(0))
+	assert.Equal(t, time.Minute, d.ReadingTime(400))
+}
@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/jdkato/prose"
 	"github.com/jdkato/prose/internal/model"
 	"github.com/jdkato/prose/internal/util"
 	"github.com/montanaflynn/stats"
@@ -149,6 +150,25 @@ func (pt *PerceptronTagger) Tag(words []string) []Token {
 	return tokens
 }
 
+// PipelineTagger returns pt as a prose.Tagger, so that it can be used as the
+// tagging stage of a prose.Pipeline (see prose.WithTagger).
+func (pt *PerceptronTagger) PipelineTagger() prose.Tagger {
+	return pipelineTagger{pt}
+}
+
+// pipelineTagger adapts a PerceptronTagger to the prose.Tagger interface.
+type pipelineTagger struct {
+	tagger *PerceptronTagger
+}
+
+func (t pipelineTagger) Tag(words []string) []prose.Token {
+	tokens := []prose.Token{}
+	for _, tok := range t.tagger.Tag(words) {
+		tokens = append(tokens, prose.Token(tok))
+	}
+	return tokens
+}
+
 // Train an Averaged Perceptron model based on sentences.
 func (pt *PerceptronTagger) Train(sentences TupleSlice, iterations int) {
 	var guess string
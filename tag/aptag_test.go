@@ -3,9 +3,11 @@ package tag
 import (
 	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jdkato/prose"
 	"github.com/jdkato/prose/internal/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -51,6 +53,22 @@ func TestTrain(t *testing.T) {
 	assert.Subset(t, tagger.Classes(), tagSet)
 }
 
+func TestPipelineTagger(t *testing.T) {
+	tagger := NewPerceptronTagger()
+	p := prose.NewPipeline(
+		prose.WithSegmenter(nil), prose.WithTagger(tagger.PipelineTagger()))
+
+	text := "The company was named a nonexecutive director ."
+	sents := p.Run(text)
+	assert.Len(t, sents, 1)
+
+	expected := []prose.Token{}
+	for _, tok := range tagger.Tag(strings.Fields(text)) {
+		expected = append(expected, prose.Token{Text: tok.Text, Tag: tok.Tag})
+	}
+	assert.Equal(t, expected, sents[0].Tokens)
+}
+
 func random(min, max int) int {
 	rand.Seed(time.Now().Unix())
 	return rand.Intn(max-min) + min
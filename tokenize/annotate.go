@@ -0,0 +1,41 @@
+package tokenize
+
+import "bytes"
+
+// boundaryMarker is inserted by Annotate at the end of every sentence but the
+// last.
+const boundaryMarker = " ‧"
+
+// Annotate returns text with a visible marker ("‧") inserted at each
+// boundary found by Sentences, which is useful for checking how a document is
+// segmented. For example,
+//
+//	I went home. It was late.
+//
+// becomes
+//
+//	I went home. ‧ It was late.
+//
+// Everything else in text, including the whitespace between sentences, is
+// left as is.
+func (p *PragmaticSegmenter) Annotate(text string) string {
+	sents := p.Sentences(text)
+
+	var b bytes.Buffer
+	last := 0
+	for i, sent := range sents {
+		if i == len(sents)-1 {
+			break
+		}
+		b.WriteString(text[last:sent.End])
+		b.WriteString(boundaryMarker)
+		if sent.Trailing == "" {
+			// There's no space to separate the marker from the next sentence
+			// (e.g., "。" in Japanese).
+			b.WriteString(" ")
+		}
+		last = sent.End
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package tokenize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotate(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := "I went home. It was late.\nThe end"
+	annotated := tok.Annotate(text)
+	assert.Equal(t, "I went home. ‧ It was late. ‧\nThe end", annotated)
+	assert.Equal(t, len(tok.Tokenize(text))-1, strings.Count(annotated, "‧"))
+
+	// Removing the markers gives back the original text.
+	assert.Equal(t, text, strings.Replace(annotated, boundaryMarker, "", -1))
+
+	for _, text := range []string{"", "   ", "Just one sentence."} {
+		assert.Equal(t, text, tok.Annotate(text))
+	}
+
+	tok, err = NewPragmaticSegmenter("en", WithAllowNoSpaceBoundaries(true))
+	util.CheckError(err)
+	assert.Equal(t, "彼は来た。 ‧ 私は帰った。", tok.Annotate("彼は来た。私は帰った。"))
+}
@@ -0,0 +1,90 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A TokenClass is the broad category of a ClassifiedToken.
+type TokenClass int
+
+// The classes assigned by TreebankWordTokenizer.Classify.
+const (
+	// Word is any token that contains a letter (e.g., "now" or "3rd").
+	Word TokenClass = iota
+	// Number is a token made of digits and separators (e.g., "5.00").
+	Number
+	// Punct is a token made of punctuation (e.g., "!" or "``").
+	Punct
+	// Symbol is a token made of symbols (e.g., "$" or "+").
+	Symbol
+	// Space is the whitespace between two tokens.
+	Space
+)
+
+// A ClassifiedToken is a single token produced by
+// TreebankWordTokenizer.Classify.
+type ClassifiedToken struct {
+	Text  string
+	Class TokenClass
+}
+
+// Classify is like Tokenize, but it also reports the class of each token.
+//
+// The whitespace that separated two tokens in text is returned as a Space
+// token, so that the tokens can be joined back into text (apart from any
+// straight double quotes, which Tokenize converts to opening and closing
+// pairs of backticks or apostrophes).
+func (t TreebankWordTokenizer) Classify(text string) []ClassifiedToken {
+	classified := []ClassifiedToken{}
+	pos := 0
+	for _, token := range t.Tokenize(text) {
+		if token == "" {
+			continue
+		}
+		// Tokenize converts straight double quotes into "``" and "''".
+		source := token
+		if token == "``" || token == "''" {
+			source = `"`
+		}
+		if idx := strings.Index(text[pos:], source); idx >= 0 {
+			if gap := text[pos : pos+idx]; gap != "" && strings.TrimSpace(gap) == "" {
+				classified = append(classified, ClassifiedToken{gap, Space})
+			}
+			pos += idx + len(source)
+		}
+		classified = append(classified, ClassifiedToken{token, classifyToken(token)})
+	}
+	return classified
+}
+
+// classifyToken returns the class of a single, non-empty token.
+func classifyToken(token string) TokenClass {
+	if token == "``" || token == "''" {
+		return Punct
+	}
+
+	digits, punct, symbols := 0, 0, 0
+	for _, r := range token {
+		switch {
+		case unicode.IsLetter(r):
+			return Word
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsSymbol(r):
+			symbols++
+		default:
+			punct++
+		}
+	}
+
+	switch {
+	case digits > 0 && symbols == 0:
+		return Number
+	case symbols > 0 && punct == 0 && digits == 0:
+		return Symbol
+	case punct > 0 && symbols == 0:
+		return Punct
+	}
+	return Symbol
+}
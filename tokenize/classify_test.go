@@ -0,0 +1,22 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreebankClassify(t *testing.T) {
+	word := NewTreebankWordTokenizer()
+	assert.Equal(t, []ClassifiedToken{
+		{"Pay", Word}, {" ", Space}, {"$", Symbol}, {"5.00", Number},
+		{" ", Space}, {"now", Word}, {"!", Punct},
+	}, word.Classify("Pay $5.00 now!"))
+
+	assert.Equal(t, []ClassifiedToken{
+		{"``", Punct}, {"I", Word}, {" ", Space}, {"do", Word},
+		{"n't", Word}, {"''", Punct}, {"  ", Space}, {"1,000", Number},
+		{" ", Space}, {"+", Symbol}, {" ", Space}, {"3rd", Word},
+		{"...", Punct},
+	}, word.Classify(`"I don't"  1,000 + 3rd...`))
+}
@@ -0,0 +1,42 @@
+package tokenize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// mojibakeReplacer fixes punctuation that was encoded as UTF-8 twice (i.e.,
+// its bytes were decoded as Windows-1252), such as "â€œ" for “. These
+// sequences don't occur in real text.
+var mojibakeReplacer = strings.NewReplacer(
+	"â€œ", "“", "â€\u009d", "”", "â€˜", "‘", "â€™", "’", "â€“", "–",
+	"â€”", "—", "â€¦", "…")
+
+var repeatedSpacesRE = regexp.MustCompile(` {2,}`)
+
+// Clean applies a few safe normalizations to text, such as scraped HTML,
+// before it's segmented:
+//
+//   - double-encoded quotes and dashes (e.g., "â€™") are repaired;
+//   - soft hyphens (U+00AD), byte order marks, and control characters other
+//     than tabs and line breaks are removed; and
+//   - runs of spaces are collapsed to a single space.
+//
+// Line breaks, tabs, and all other characters are left as is. Since Clean
+// can change the length of text, the offsets reported by Sentences refer to
+// its output rather than to the original text. Use WithClean to instead
+// clean each sentence as it's emitted.
+func Clean(text string) string {
+	text = mojibakeReplacer.Replace(text)
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			return r
+		case r == '\u00ad' || r == '\ufeff' || unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text)
+	return repeatedSpacesRE.ReplaceAllString(text, " ")
+}
@@ -0,0 +1,40 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClean(t *testing.T) {
+	for dirty, clean := range map[string]string{
+		"The in\u00adter\u00adnational  team\x00 won.":     "The international team won.",
+		"\ufeffIt was   late.\x1b Very\x07 late.":          "It was late. Very late.",
+		"â€œHi,â€\u009d she said. Itâ€™s fine â€” really.": "“Hi,” she said. It’s fine — really.",
+		"Tabs\tand\nline  breaks\r\nstay.":                 "Tabs\tand\nline breaks\r\nstay.",
+		"Café, naïve, 東京, and 42 are unchanged.":           "Café, naïve, 東京, and 42 are unchanged.",
+	} {
+		assert.Equal(t, clean, Clean(dirty), "%q", dirty)
+	}
+}
+
+func TestPragmaticClean(t *testing.T) {
+	text := "The in\u00adter\u00adnational  team\x00 won.  Itâ€™s true."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"The in\u00adter\u00adnational  team\x00 won.", "Itâ€™s true."},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithClean(true))
+	util.CheckError(err)
+	assert.Equal(t, []string{"The international team won.", "It’s true."},
+		tok.Tokenize(text))
+
+	// The offsets still refer to the original text.
+	sents := tok.Sentences(text)
+	assert.Equal(t, "It’s true.", sents[1].Normalized)
+	assert.Equal(t, "Itâ€™s true.", text[sents[1].Start:sents[1].End])
+}
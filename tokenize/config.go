@@ -0,0 +1,173 @@
+package tokenize
+
+import (
+	"encoding/json"
+	"unicode/utf8"
+)
+
+// A Config is a serializable description of a PragmaticSegmenter's settings.
+//
+// It can be marshaled to JSON (e.g., to share a configuration across
+// services) and turned back into an equivalent segmenter by NewFromConfig.
+// A validator registered by WithSentenceStartValidator is a function, so it
+// isn't part of a Config.
+type Config struct {
+	// Language is the segmenter's two-character ISO 639-1 code.
+	Language string `json:"language"`
+	// Terminators holds the additional characters registered by
+	// WithTerminators.
+	Terminators string `json:"terminators,omitempty"`
+	// PreRules and PostRules hold the rules registered by WithPreRules and
+	// WithPostRules, respectively.
+	PreRules  []Rule `json:"preRules,omitempty"`
+	PostRules []Rule `json:"postRules,omitempty"`
+	// QuotePairs holds the pairs registered by WithQuotePairs, if they
+	// differ from the language's own, as a sequence of opening and closing
+	// marks (e.g., "„“「」").
+	QuotePairs *string `json:"quotePairs,omitempty"`
+	// Abbreviations holds the normalized abbreviations registered by
+	// WithAbbreviations.
+	Abbreviations []string `json:"abbreviations,omitempty"`
+	// DashTarget holds the target registered by WithDashNormalization, if
+	// any.
+	DashTarget string `json:"dashTarget,omitempty"`
+
+	// CollapseRepeatedTerminators is set if the segmenter was created using
+	// WithPreserveRepeatedTerminators(false).
+	CollapseRepeatedTerminators bool `json:"collapseRepeatedTerminators"`
+
+	// NoQuoteProtection is set if the segmenter was created using
+	// WithQuoteProtection(false).
+	NoQuoteProtection bool `json:"noQuoteProtection"`
+
+	// The remaining fields correspond to the options of the same name.
+	FastPath               bool `json:"fastPath"`
+	Scanner                bool `json:"scanner"`
+	WhitespaceCollapse     bool `json:"whitespaceCollapse"`
+	PreservedLineEndings   bool `json:"preservedLineEndings"`
+	RequireTerminator      bool `json:"requireTerminator"`
+	ListItems              bool `json:"listItems"`
+	EmDashBoundaries       bool `json:"emDashBoundaries"`
+	BlankLineBoundaries    bool `json:"blankLineBoundaries"`
+	TabBoundaries          bool `json:"tabBoundaries"`
+	AllowNoSpaceBoundaries bool `json:"allowNoSpaceBoundaries"`
+	NormalizedDashOutput   bool `json:"normalizedDashOutput"`
+	StripOuterQuotes       bool `json:"stripOuterQuotes"`
+	Clean                  bool `json:"clean"`
+	Lookahead              int  `json:"lookahead"`
+	MergeShortFragments    int  `json:"mergeShortFragments"`
+	MinSentenceRunes       int  `json:"minSentenceRunes"`
+}
+
+// Config returns the settings that p was created with.
+func (p *PragmaticSegmenter) Config() Config {
+	c := Config{
+		Language:                    p.lang,
+		Terminators:                 string(p.opts.terminators),
+		PreRules:                    append([]Rule{}, p.opts.preRules...),
+		PostRules:                   append([]Rule{}, p.opts.postRules...),
+		Abbreviations:               append([]string{}, p.opts.abbreviations...),
+		FastPath:                    p.opts.fastPath,
+		Scanner:                     p.opts.scanner,
+		WhitespaceCollapse:          p.opts.collapseWhitespace,
+		PreservedLineEndings:        p.opts.keepLineEndings,
+		RequireTerminator:           p.opts.requireTerminator,
+		ListItems:                   p.opts.listItems,
+		EmDashBoundaries:            p.opts.emDashBoundaries,
+		BlankLineBoundaries:         p.opts.blankLines,
+		TabBoundaries:               p.opts.tabs,
+		AllowNoSpaceBoundaries:      p.opts.noSpace,
+		NormalizedDashOutput:        p.opts.dashOutput,
+		StripOuterQuotes:            p.opts.stripOuterQuotes,
+		Clean:                       p.opts.clean,
+		Lookahead:                   p.opts.lookahead,
+		MergeShortFragments:         p.opts.minRunes,
+		MinSentenceRunes:            p.opts.minSentenceRunes,
+		CollapseRepeatedTerminators: p.opts.collapseRepeated,
+		NoQuoteProtection:           p.opts.noQuotes,
+	}
+	defaults := defaultOptions(p.processor.language())
+	if !equalQuotePairs(p.opts.quotePairs, defaults.quotePairs) {
+		pairs := ""
+		for _, pair := range p.opts.quotePairs {
+			pairs += string(pair.Open) + string(pair.Close)
+		}
+		c.QuotePairs = &pairs
+	}
+	if p.opts.dashTarget != 0 {
+		c.DashTarget = string(p.opts.dashTarget)
+	}
+	return c
+}
+
+// NewFromConfig creates a new PragmaticSegmenter from the given settings. It
+// returns the same errors as NewPragmaticSegmenter.
+func NewFromConfig(c Config) (*PragmaticSegmenter, error) {
+	var dashTarget rune
+	if c.DashTarget != "" {
+		dashTarget, _ = utf8.DecodeRuneInString(c.DashTarget)
+	}
+	opts := []SegmenterOption{
+		WithTerminators([]rune(c.Terminators)),
+		WithPreRules(c.PreRules...),
+		WithPostRules(c.PostRules...),
+		WithAbbreviations(c.Abbreviations...),
+		WithFastPath(c.FastPath),
+		WithScanner(c.Scanner),
+		WithWhitespaceCollapse(c.WhitespaceCollapse),
+		WithPreservedLineEndings(c.PreservedLineEndings),
+		WithRequireTerminator(c.RequireTerminator),
+		WithListItems(c.ListItems),
+		WithEmDashBoundaries(c.EmDashBoundaries),
+		WithBlankLineBoundaries(c.BlankLineBoundaries),
+		WithTabBoundaries(c.TabBoundaries),
+		WithAllowNoSpaceBoundaries(c.AllowNoSpaceBoundaries),
+		WithDashNormalization(dashTarget),
+		WithNormalizedDashOutput(c.NormalizedDashOutput),
+		WithStripOuterQuotes(c.StripOuterQuotes),
+		WithClean(c.Clean),
+		WithPreserveRepeatedTerminators(!c.CollapseRepeatedTerminators),
+		WithQuoteProtection(!c.NoQuoteProtection),
+		WithLookahead(c.Lookahead),
+		WithMergeShortFragments(c.MergeShortFragments),
+		WithMinSentenceRunes(c.MinSentenceRunes)}
+	if c.QuotePairs != nil {
+		marks := []rune(*c.QuotePairs)
+		pairs := []QuotePair{}
+		for i := 0; i+1 < len(marks); i += 2 {
+			pairs = append(pairs, QuotePair{Open: marks[i], Close: marks[i+1]})
+		}
+		opts = append(opts, WithQuotePairs(pairs))
+	}
+	return NewPragmaticSegmenter(c.Language, opts...)
+}
+
+type jsonRule struct {
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+}
+
+// MarshalJSON encodes r as an object holding its pattern's source text and
+// its replacement.
+func (r Rule) MarshalJSON() ([]byte, error) {
+	rule := jsonRule{Replacement: r.Replacement}
+	if r.Pattern != nil {
+		rule.Pattern = r.Pattern.String()
+	}
+	return json.Marshal(rule)
+}
+
+// UnmarshalJSON decodes a Rule encoded by MarshalJSON, compiling its pattern
+// (or reusing one compiled by CompileRule).
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	var rule jsonRule
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return err
+	}
+	pattern, err := compilePattern(rule.Pattern)
+	if err != nil {
+		return err
+	}
+	r.Pattern, r.Replacement = pattern, rule.Replacement
+	return nil
+}
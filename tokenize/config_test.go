@@ -0,0 +1,41 @@
+package tokenize
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	initials := Rule{Pattern: regexp.MustCompile(`\b(J\.)\s`), Replacement: "Jay"}
+	tok, err := NewPragmaticSegmenter("en",
+		WithTerminators([]rune{'|'}),
+		WithPreRules(initials),
+		WithWhitespaceCollapse(true),
+		WithLookahead(4))
+	util.CheckError(err)
+
+	data, err := json.Marshal(tok.Config())
+	util.CheckError(err)
+
+	var config Config
+	util.CheckError(json.Unmarshal(data, &config))
+	assert.Equal(t, tok.Config().PreRules[0].Pattern.String(),
+		config.PreRules[0].Pattern.String())
+
+	loaded, err := NewFromConfig(config)
+	util.CheckError(err)
+
+	text := "J. left|  She  stayed. Then  he  returned."
+	assert.Equal(t, tok.Tokenize(text), loaded.Tokenize(text))
+	assert.Equal(t, []string{
+		"Jay left|", "She stayed.", "Then he returned."}, loaded.Tokenize(text))
+
+	_, err = NewFromConfig(Config{Language: "xx"})
+	assert.Error(t, err)
+	assert.Error(t, json.Unmarshal(
+		[]byte(`{"preRules": [{"pattern": "("}]}`), &config))
+}
@@ -0,0 +1,57 @@
+package tokenize
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// dashes holds the characters that are replaced by WithDashNormalization:
+// the hyphen-minus, hyphen, non-breaking hyphen, figure dash, en dash, em
+// dash, horizontal bar, minus sign, and the small and full-width forms.
+const dashes = "-‐‑‒–—―−﹘﹣－"
+
+// newDashRule creates a Rule that replaces each of dashes with target.
+func newDashRule(target rune) *Rule {
+	return &Rule{
+		Pattern:     regexp.MustCompile(`([` + regexp.QuoteMeta(dashes) + `])`),
+		Replacement: string(target)}
+}
+
+// restoreDashes undoes the work of a dash rule with the given target by
+// copying the original dashes from text back into sents.
+//
+// Each dash is replaced by a single character, so the sentences can be
+// aligned with text in the same way as the offsets reported by Sentences.
+func restoreDashes(text string, sents []string, target rune) {
+	for i, loc := range align(text, sents) {
+		if !strings.ContainsRune(sents[i], target) {
+			continue
+		}
+
+		var b bytes.Buffer
+		pos := loc[0]
+		for _, r := range sents[i] {
+			if unicode.IsSpace(r) {
+				b.WriteRune(r)
+				continue
+			}
+			for pos < loc[1] {
+				c, size := utf8.DecodeRuneInString(text[pos:])
+				if !unicode.IsSpace(c) {
+					break
+				}
+				pos += size
+			}
+			c, size := utf8.DecodeRuneInString(text[pos:])
+			if r == target && strings.ContainsRune(dashes, c) {
+				r = c
+			}
+			b.WriteRune(r)
+			pos += size
+		}
+		sents[i] = b.String()
+	}
+}
@@ -0,0 +1,77 @@
+package tokenize
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDashRule(t *testing.T) {
+	rule := newDashRule('-')
+	for _, dash := range []string{
+		"-", // hyphen-minus
+		"‐", // hyphen
+		"‑", // non-breaking hyphen
+		"‒", // figure dash
+		"–", // en dash
+		"—", // em dash
+		"―", // horizontal bar
+		"−", // minus sign
+		"﹘", // small em dash
+		"﹣", // small hyphen-minus
+		"－", // full-width hyphen-minus
+	} {
+		assert.Equal(t, "a-b", rule.Sub("a"+dash+"b"), "%U", []rune(dash)[0])
+	}
+	assert.Equal(t, "a—b—c", newDashRule('—').Sub("a–b−c"))
+	assert.Equal(t, "a~b", newDashRule('-').Sub("a~b"))
+}
+
+func TestPragmaticDashNormalization(t *testing.T) {
+	// A pre-rule that only knows about the hyphen-minus starts a new segment
+	// at a spaced dash, whichever dash was actually used.
+	spaced := Rule{
+		Pattern: regexp.MustCompile(`\S( )-\s\p{Lu}`), Replacement: "\n"}
+
+	tok, err := NewPragmaticSegmenter("en",
+		WithDashNormalization('-'), WithPreRules(spaced))
+	util.CheckError(err)
+
+	text := "Doors open at 8 – Bring a coat. It’s a two‐part show—stay for both."
+	assert.Equal(t, []string{
+		"Doors open at 8",
+		"– Bring a coat.",
+		"It’s a two‐part show—stay for both.",
+	}, tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithPreRules(spaced))
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"Doors open at 8 – Bring a coat.",
+		"It’s a two‐part show—stay for both.",
+	}, tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en",
+		WithDashNormalization('-'), WithNormalizedDashOutput(true),
+		WithPreRules(spaced))
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"Doors open at 8",
+		"- Bring a coat.",
+		"It’s a two-part show-stay for both.",
+	}, tok.Tokenize(text))
+
+	// Dashes are restored even when the whitespace around them changes.
+	tok, err = NewPragmaticSegmenter("en",
+		WithDashNormalization('-'), WithWhitespaceCollapse(true))
+	util.CheckError(err)
+	assert.Equal(t, []string{"A – B.", "C − D."},
+		tok.Tokenize("A  –  B.\n\tC − D."))
+
+	loaded, err := NewFromConfig(tok.Config())
+	util.CheckError(err)
+	assert.Equal(t, "-", loaded.Config().DashTarget)
+	assert.Equal(t, tok.Tokenize(text), loaded.Tokenize(text))
+}
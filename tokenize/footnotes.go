@@ -0,0 +1,41 @@
+package tokenize
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// footnoteRE matches a terminator followed by a footnote marker made of
+// superscript digits (e.g., "claim.¹ The study ..."), which belongs to the
+// sentence that the terminator ends.
+var footnoteRE = regexp.MustCompile(`([.!?。．！？‽‼⁇⁈⁉⸮])([⁰¹²³⁴-⁹]+)(?:\s|ȸ|$)`)
+
+// markFootnotes moves each boundary that's followed by a footnote marker to
+// the end of the marker: the terminator is masked, and the marker is followed
+// by "ȸ" (which, like the boundaries of newClosingBracketRules, is removed
+// before the sentences are returned).
+func markFootnotes(text string) string {
+	matches := footnoteRE.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+
+	var buf bytes.Buffer
+	last := 0
+	for _, m := range matches {
+		terminator := text[m[2]:m[3]]
+		for _, mask := range punctuationMasks {
+			if mask.glyph == terminator {
+				terminator = mask.sentinel
+				break
+			}
+		}
+		buf.WriteString(text[last:m[2]])
+		buf.WriteString(terminator)
+		buf.WriteString(text[m[4]:m[5]])
+		buf.WriteString("ȸ")
+		last = m[5]
+	}
+	buf.WriteString(text[last:])
+	return buf.String()
+}
@@ -0,0 +1,31 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// mergeShortFragments joins each of sents that has fewer than minRunes runes
+// to the sentence before it. Fragments at the start of sents are instead
+// joined to the first sentence that follows them.
+func mergeShortFragments(sents []string, minRunes int) []string {
+	merged := []string{}
+	leading := []string{}
+	for _, sent := range sents {
+		short := utf8.RuneCountInString(sent) < minRunes
+		switch {
+		case short && len(merged) > 0:
+			merged[len(merged)-1] += " " + sent
+		case short:
+			leading = append(leading, sent)
+		default:
+			merged = append(merged, strings.Join(append(leading, sent), " "))
+			leading = leading[:0]
+		}
+	}
+	if len(leading) > 0 {
+		// Every segment was a fragment.
+		merged = append(merged, strings.Join(leading, " "))
+	}
+	return merged
+}
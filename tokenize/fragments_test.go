@@ -0,0 +1,65 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeShortFragments(t *testing.T) {
+	text := "Figure 3 shows the results.\nA.\nThe values rise."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"Figure 3 shows the results.", "A.", "The values rise."},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithMergeShortFragments(3))
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"Figure 3 shows the results. A.", "The values rise."},
+		tok.Tokenize(text))
+	assert.Equal(t, []string{
+		"Figure 3 shows the results.\nA.", "The values rise."},
+		texts(tok.Sentences(text)))
+
+	// A leading fragment is attached to the sentence that follows it.
+	assert.Equal(t, []string{"A. The values rise."},
+		tok.Tokenize("A.\nThe values rise."))
+	assert.Equal(t, []string{"Wait.", "Go on!"}, tok.Tokenize("Wait. Go on!"))
+
+	for _, test := range []struct{ sents, expected []string }{
+		{[]string{"A.", "B."}, []string{"A. B."}},
+		{[]string{"A.", "Done.", "B."}, []string{"A. Done. B."}},
+		{[]string{}, []string{}},
+	} {
+		assert.Equal(t, test.expected, mergeShortFragments(test.sents, 3))
+	}
+}
+
+func TestMinSentenceRunes(t *testing.T) {
+	text := "Hi. I'm Bob. Bye."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{"Hi.", "I'm Bob.", "Bye."}, tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithMinSentenceRunes(5))
+	util.CheckError(err)
+	assert.Equal(t, []string{"Hi. I'm Bob.", "Bye."}, tok.Tokenize(text))
+	assert.Equal(t, []string{"Hi. I'm Bob.", "Bye."}, texts(tok.Sentences(text)))
+
+	// Unlike WithMergeShortFragments, the short sentence is joined to the
+	// next one rather than the previous one.
+	merged, err := NewPragmaticSegmenter("en", WithMergeShortFragments(5))
+	util.CheckError(err)
+	assert.Equal(t, []string{"Hi. I'm Bob. Bye."}, merged.Tokenize(text))
+
+	// Sentinels don't count toward the minimum.
+	tok, err = NewPragmaticSegmenter("en", WithMinSentenceRunes(9))
+	util.CheckError(err)
+	assert.Equal(t, []string{"Go (x!). Then stop."}, tok.Tokenize("Go (x!). Then stop."))
+	assert.Equal(t, []string{"Hi.", "Bye now."}, tok.Tokenize("Hi.\nBye now."))
+}
@@ -0,0 +1,74 @@
+package tokenize
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockElements are the HTML elements whose boundaries are also sentence
+// boundaries; all other elements (e.g., <a> or <em>) are transparent.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"br": true, "caption": true, "dd": true, "div": true, "dl": true,
+	"dt": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "li": true, "main": true,
+	"nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"table": true, "td": true, "th": true, "title": true, "tr": true,
+	"ul": true,
+}
+
+// skippedElements are the HTML elements whose content isn't prose.
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+}
+
+// TokenizeHTML splits the text content of an HTML document (or fragment) into
+// sentences.
+//
+// Tags are removed and entities are unescaped. The start or end of a
+// block-level element (such as <p>, <li>, or <br>) always ends the current
+// sentence, while inline elements are ignored.
+func (p *PragmaticSegmenter) TokenizeHTML(markup string) []string {
+	sents := []string{}
+
+	var block bytes.Buffer
+	flush := func() {
+		if strings.TrimSpace(block.String()) != "" {
+			sents = append(sents, p.Tokenize(block.String())...)
+		}
+		block.Reset()
+	}
+
+	skipping := ""
+	z := html.NewTokenizer(strings.NewReader(markup))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			flush()
+			return sents
+		case html.TextToken:
+			if skipping == "" {
+				block.Write(z.Text())
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if skippedElements[tag] {
+				skipping = tag
+			} else if blockElements[tag] {
+				flush()
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if tag == skipping {
+				skipping = ""
+			} else if blockElements[tag] {
+				flush()
+			}
+		}
+	}
+}
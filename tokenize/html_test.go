@@ -0,0 +1,26 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeHTML(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	assert.Equal(t, []string{"Hello.", "World."},
+		tok.TokenizeHTML("<p>Hello.</p><p>World.</p>"))
+	assert.Equal(t, []string{"First item", "Second item"},
+		tok.TokenizeHTML("<ul><li>First item</li><li>Second item</li></ul>"))
+	assert.Equal(t, []string{"Line one", "Line two"},
+		tok.TokenizeHTML("Line one<br>Line two"))
+	assert.Equal(t, []string{
+		"I can see Mt. Fuji from here.", "It's <very> tall & wide."},
+		tok.TokenizeHTML(
+			"<p>I can see <a href=\"#\">Mt. Fuji</a> from <em>here</em>. "+
+				"It's &lt;very&gt; tall &amp; wide.</p>"+
+				"<script>var x = 'Not. Text.';</script>"))
+}
@@ -0,0 +1,97 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Incremental segments a stream of text that arrives in pieces (for example,
+// tokens from a chat message), emitting each sentence as soon as its boundary
+// is settled.
+//
+// Text following the last whitespace character is always held back, since it
+// may be an incomplete word that changes the boundary decision (e.g., "U.S. G"
+// could become "U.S. Government"). The final sentence is likewise held back
+// until more text arrives or Flush is called.
+type Incremental struct {
+	segmenter *PragmaticSegmenter
+	buffer    string
+	err       error
+}
+
+// NewIncremental creates a new Incremental that uses segmenter to identify
+// sentence boundaries.
+func NewIncremental(segmenter *PragmaticSegmenter) *Incremental {
+	return &Incremental{segmenter: segmenter}
+}
+
+// Feed appends chunk to the buffered text and returns any sentences that have
+// been completed as a result.
+func (inc *Incremental) Feed(chunk string) []string {
+	inc.buffer += chunk
+
+	idx := strings.LastIndexFunc(inc.buffer, unicode.IsSpace)
+	if idx < 0 {
+		return nil
+	}
+
+	settled := inc.buffer[:idx]
+	sents, err := inc.segmenter.processor.process(settled)
+	inc.setErr(err)
+	if len(sents) < 2 {
+		return nil
+	}
+
+	done := sents[:len(sents)-1]
+	offsets := inc.segmenter.processor.locate(settled, done)
+	inc.buffer = inc.buffer[offsets[len(offsets)-1][1]:]
+
+	return inc.output(done)
+}
+
+// Flush returns the sentences remaining in the buffer, which is then reset.
+//
+// If the segmenter was created using WithRequireTerminator(true), a trailing
+// fragment is kept in the buffer instead.
+func (inc *Incremental) Flush() []string {
+	text := inc.buffer
+	inc.buffer = ""
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	sents, err := inc.segmenter.processor.process(text)
+	inc.setErr(err)
+	if inc.segmenter.opts.requireTerminator && inc.segmenter.isFragment(sents) {
+		offsets := inc.segmenter.processor.locate(text, sents)
+		inc.buffer = text[offsets[len(offsets)-1][0]:]
+		sents = sents[:len(sents)-1]
+	}
+	return inc.output(sents)
+}
+
+// Err returns the first error encountered by Feed or Flush, if any. As with
+// Segment, this is a custom rule that failed to converge (as a *RuleError),
+// which is skipped rather than ending the stream.
+func (inc *Incremental) Err() error {
+	return inc.err
+}
+
+// setErr records err unless an earlier error has already been recorded.
+func (inc *Incremental) setErr(err error) {
+	if inc.err == nil {
+		inc.err = err
+	}
+}
+
+// output applies the segmenter's post-rules and output-only options, if any,
+// to sents. This happens last, since the buffer is tracked using the sentences
+// as they appear in the text.
+func (inc *Incremental) output(sents []string) []string {
+	sents, err := inc.segmenter.applyPostRules(sents)
+	inc.setErr(err)
+	for i, sent := range sents {
+		sents[i] = inc.segmenter.output(sent)
+	}
+	return sents
+}
@@ -0,0 +1,69 @@
+package tokenize
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncremental(t *testing.T) {
+	seg, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	inc := NewIncremental(seg)
+	assert.Empty(t, inc.Feed("I can see "))
+	assert.Empty(t, inc.Feed("Mt. Fuji from "))
+	assert.Empty(t, inc.Feed("here. It"))
+	assert.Equal(t, []string{"I can see Mt. Fuji from here."}, inc.Feed(" is"))
+	assert.Empty(t, inc.Feed(" tall"))
+	assert.Empty(t, inc.Feed("."))
+	assert.Equal(t, []string{"It is tall."}, inc.Flush())
+	assert.Empty(t, inc.Flush())
+}
+
+func TestIncrementalLookahead(t *testing.T) {
+	seg, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	inc := NewIncremental(seg)
+	sents := []string{}
+	for _, chunk := range []string{"I work for the U.", "S. Gov", "ernment in ", "Virginia. Bye."} {
+		sents = append(sents, inc.Feed(chunk)...)
+	}
+	sents = append(sents, inc.Flush()...)
+	assert.Equal(t, []string{
+		"I work for the U.S. Government in Virginia.", "Bye."}, sents)
+}
+
+func TestIncrementalRequireTerminator(t *testing.T) {
+	seg, err := NewPragmaticSegmenter("en", WithRequireTerminator(true))
+	util.CheckError(err)
+
+	inc := NewIncremental(seg)
+	assert.Equal(t, []string{"It is tall."}, inc.Feed("It is tall. And it"))
+	assert.Empty(t, inc.Flush())
+	assert.Empty(t, inc.Feed(" is wide"))
+	assert.Empty(t, inc.Flush())
+	assert.Empty(t, inc.Feed("."))
+	assert.Equal(t, []string{"And it is wide."}, inc.Flush())
+}
+
+func TestIncrementalErr(t *testing.T) {
+	// "ab" -> "abb" -> "abbb" -> ... never stops.
+	growing := Rule{Pattern: regexp.MustCompile(`a(b)`), Replacement: "bb"}
+	seg, err := NewPragmaticSegmenter("en", WithPreRules(growing))
+	util.CheckError(err)
+
+	inc := NewIncremental(seg)
+	assert.Equal(t, []string{"Hello there."}, inc.Feed("Hello there. Bye "))
+	assert.NoError(t, inc.Err())
+
+	// The rule is skipped, so the sentences are still returned.
+	assert.Equal(t, []string{"Bye now."}, inc.Feed("now. I said ab. "))
+	assert.Equal(t, []string{"I said ab."}, inc.Flush())
+
+	_, isRuleErr := inc.Err().(*RuleError)
+	assert.True(t, isRuleErr)
+}
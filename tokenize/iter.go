@@ -0,0 +1,51 @@
+//go:build go1.23
+// +build go1.23
+
+package tokenize
+
+import (
+	"io"
+	"iter"
+)
+
+// All reads text from r and yields each of its sentences as soon as it's
+// complete, in the same way as SegmentStream. An error from r is yielded, with
+// an empty sentence, and ends the sequence. A custom rule that failed to
+// converge (see Incremental.Err) is reported in the same way, after the last
+// sentence.
+//
+// The input is only read as more sentences are requested, so breaking out of
+// a loop over the sequence stops reading from r:
+//
+//	for sent, err := range seg.All(r) {
+//		...
+//	}
+func (p *PragmaticSegmenter) All(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		inc := NewIncremental(p)
+		buf := make([]byte, streamChunkSize)
+		for {
+			n, readErr := r.Read(buf)
+			for _, sent := range inc.Feed(string(buf[:n])) {
+				if !yield(sent, nil) {
+					return
+				}
+			}
+			if readErr == io.EOF {
+				break
+			} else if readErr != nil {
+				yield("", readErr)
+				return
+			}
+		}
+
+		for _, sent := range inc.Flush() {
+			if !yield(sent, nil) {
+				return
+			}
+		}
+		if err := inc.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
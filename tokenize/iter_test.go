@@ -0,0 +1,63 @@
+//go:build go1.23
+// +build go1.23
+
+package tokenize
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingReader records how many bytes have been read from it.
+type countingReader struct {
+	r    io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read += n
+	return n, err
+}
+
+func TestPragmaticAll(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	sents := []string{}
+	for sent, err := range tok.All(strings.NewReader("Hello world. How are you? Fine")) {
+		util.CheckError(err)
+		sents = append(sents, sent)
+	}
+	assert.Equal(t, []string{"Hello world.", "How are you?", "Fine"}, sents)
+
+	text := strings.Repeat("This is one of many sentences. ", 1000)
+	r := &countingReader{r: strings.NewReader(text)}
+	for sent, err := range tok.All(r) {
+		util.CheckError(err)
+		assert.Equal(t, "This is one of many sentences.", sent)
+		break
+	}
+	assert.Equal(t, streamChunkSize, r.read)
+
+	failing := io.MultiReader(strings.NewReader("One. Two. "),
+		&errorReader{errors.New("broken")})
+	sents = []string{}
+	for sent, err := range tok.All(failing) {
+		if err != nil {
+			assert.EqualError(t, err, "broken")
+			break
+		}
+		sents = append(sents, sent)
+	}
+	assert.Equal(t, []string{"One."}, sents)
+}
+
+type errorReader struct{ err error }
+
+func (e *errorReader) Read(p []byte) (int, error) { return 0, e.err }
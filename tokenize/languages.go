@@ -0,0 +1,161 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// A LangSentence is a sentence found by SegmentWithLanguages, along with the
+// ISO 639-1 code of the language that it's written in.
+type LangSentence struct {
+	Sentence
+	Lang string
+}
+
+// scriptLanguages maps each non-Latin script that identifies a language on
+// its own to that language. Han is handled separately, since it's shared by
+// Chinese and Japanese.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Hiragana, "ja"}, {unicode.Katakana, "ja"}, {unicode.Hangul, "ko"},
+	{unicode.Thai, "th"}, {unicode.Greek, "el"}, {unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"}, {unicode.Hebrew, "he"},
+}
+
+// stopwords lists common function words for each of the supported languages
+// that are written in the Latin script.
+var stopwords = map[string][]string{
+	"en": {"a", "and", "are", "for", "he", "i", "in", "is", "it", "not", "of",
+		"she", "that", "the", "they", "this", "to", "was", "we", "with", "you"},
+	"fr": {"avec", "dans", "des", "du", "elle", "est", "et", "il", "je", "la",
+		"le", "les", "ne", "nous", "pas", "pour", "que", "qui", "sur", "un",
+		"une", "vous"},
+	"es": {"con", "del", "el", "es", "está", "las", "los", "muy", "no", "para",
+		"por", "que", "se", "un", "una", "y", "yo"},
+	"de": {"auf", "das", "dem", "den", "der", "die", "ein", "eine", "er", "ich",
+		"ist", "mit", "nicht", "sie", "und", "von", "wir", "zu"},
+	"tr": {"bir", "bu", "çok", "da", "değil", "gibi", "için", "ile", "mi", "ve",
+		"var", "yok"},
+}
+
+// detectLanguage guesses the language of text: by its script if that's
+// enough (e.g., kana for Japanese) or else, for Latin text, by counting the
+// stopwords of each supported language. It defaults to English.
+func detectLanguage(text string) string {
+	counts := map[string]int{}
+	han, latin := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+		for _, s := range scriptLanguages {
+			if unicode.Is(s.script, r) {
+				counts[s.lang]++
+			}
+		}
+	}
+	if counts["ja"] > 0 {
+		// Japanese mixes kana with Han characters.
+		counts["ja"] += han
+	} else {
+		counts["zh"] = han
+	}
+
+	best, most := "", latin
+	for lang, n := range counts {
+		if n > most || (n == most && n > 0 && lang < best) {
+			best, most = lang, n
+		}
+	}
+	if best != "" {
+		return best
+	}
+	return latinLanguage(text)
+}
+
+// latinLanguage returns the language whose stopwords occur most often in
+// text, preferring English in the case of a tie.
+func latinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	best, most := "en", 0
+	for _, lang := range []string{"en", "fr", "es", "de", "tr"} {
+		n := 0
+		for _, word := range words {
+			for _, stop := range stopwords[lang] {
+				if word == stop {
+					n++
+					break
+				}
+			}
+		}
+		if n > most {
+			best, most = lang, n
+		}
+	}
+	return best
+}
+
+// SegmentWithLanguages splits text, which may be written in several
+// languages, into sentences and labels each of them with the language that
+// it's written in.
+//
+// The text is first segmented using the English rules, which split on any
+// terminator (e.g., "." or "。"). Each run of consecutive sentences in another
+// supported language (see NewPragmaticSegmenter) is then segmented again
+// using that language's rules, such as its abbreviations. A sentence in an
+// unsupported language (e.g., Japanese) is still labeled (as "ja"), but it
+// keeps the English segmentation.
+//
+// Language detection is a simple heuristic based on the script of each
+// sentence and, for Latin text, on common words, so short sentences may be
+// mislabeled.
+func SegmentWithLanguages(text string) []LangSentence {
+	base, _ := NewPragmaticSegmenter("en")
+	sents := base.Sentences(text)
+
+	labeled := []LangSentence{}
+	for i := 0; i < len(sents); {
+		lang := detectLanguage(sents[i].Text)
+		end := i + 1
+		for end < len(sents) && detectLanguage(sents[end].Text) == lang {
+			end++
+		}
+		for _, sent := range resegment(text, sents[i:end], lang) {
+			labeled = append(labeled, LangSentence{Sentence: sent, Lang: lang})
+		}
+		i = end
+	}
+	return labeled
+}
+
+// resegment splits the part of text covered by run, a series of consecutive
+// sentences, using the rules for lang. run is returned as is if lang is
+// English or isn't supported.
+func resegment(text string, run []Sentence, lang string) []Sentence {
+	if _, ok := langToProcessor[lang]; !ok || lang == "en" {
+		return run
+	}
+	seg, err := NewPragmaticSegmenter(lang)
+	if err != nil {
+		return run
+	}
+
+	first, last := run[0], run[len(run)-1]
+	sents := seg.Sentences(text[first.Start:last.End])
+	for i := range sents {
+		sents[i].Start += first.Start
+		sents[i].End += first.Start
+		sents[i].Line += first.Line - 1
+	}
+	// The run's last boundary was found in the context of the whole text.
+	sents[len(sents)-1].Trailing = last.Trailing
+	sents[len(sents)-1].Confidence = last.Confidence
+	return sents
+}
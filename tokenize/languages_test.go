@@ -0,0 +1,51 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	for text, lang := range map[string]string{
+		"I went home.":                     "en",
+		"私は家に帰りました。":                       "ja",
+		"我回家了。":                            "zh",
+		"Это было поздно.":                 "ru",
+		"Ήταν αργά.":                       "el",
+		"วันนี้อากาศดีมาก":                 "th",
+		"Je ne sais pas ce que tu veux.":   "fr",
+		"El perro es muy grande y bonito.": "es",
+		"Ich weiß nicht, was er will.":     "de",
+		"Bu çok güzel bir gün.":            "tr",
+		"42":                               "en",
+	} {
+		assert.Equal(t, lang, detectLanguage(text), text)
+	}
+}
+
+func TestSegmentWithLanguages(t *testing.T) {
+	text := "I went home. 私は家に帰りました。"
+	sents := SegmentWithLanguages(text)
+	assert.Equal(t, 2, len(sents))
+	assert.Equal(t, "I went home.", sents[0].Text)
+	assert.Equal(t, "en", sents[0].Lang)
+	assert.Equal(t, "私は家に帰りました。", sents[1].Text)
+	assert.Equal(t, "ja", sents[1].Lang)
+	for _, sent := range sents {
+		assert.Equal(t, sent.Text, text[sent.Start:sent.End])
+	}
+
+	// Runs in a supported language use its own rules: "проф." is a Russian
+	// abbreviation, but not an English one.
+	text = "It was late.\nЭто проф. Иванов. Он спал."
+	sents = SegmentWithLanguages(text)
+	assert.Equal(t, 3, len(sents))
+	assert.Equal(t, []string{"en", "ru", "ru"}, []string{
+		sents[0].Lang, sents[1].Lang, sents[2].Lang})
+	assert.Equal(t, "Это проф. Иванов.", sents[1].Text)
+	assert.Equal(t, 2, sents[1].Line)
+	for _, sent := range sents {
+		assert.Equal(t, sent.Text, text[sent.Start:sent.End])
+	}
+}
@@ -0,0 +1,169 @@
+package tokenize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lineItemRE matches the start of a line that's part of a list (e.g., "1.",
+// "a)", "-", or "•"), which is never joined to its neighbors.
+var lineItemRE = regexp.MustCompile(`^(?:\d+[.)]|[a-zA-Z][.)]\s|[-*•●▪‣·–—]\s)`)
+
+// joinLines joins each run of lines that together form a single sentence,
+// such as an address or a verse, which would otherwise be split at every line
+// break:
+//
+//	123 Main St.
+//	Apt 4
+//	Boston, MA.
+//
+// A run is two or more lines, separated by single line breaks, that each hold
+// exactly one of sents. Every line but the last must be open (that is, it
+// doesn't end with terminal punctuation, or it ends with a known abbreviation
+// and the next line is also open) and the last line must end with terminal
+// punctuation. To keep headings (and other lists of short lines) from being
+// joined to the sentence below them, an open line must also end with a comma,
+// semicolon, colon, or abbreviation, unless the line before it in the run
+// does. This allows for a single bare line, like "Apt 4" above, between two
+// that continue.
+//
+// Similarly, a list item whose first line is open continues onto each of the
+// indented lines that follow it, up to the one that ends with terminal
+// punctuation or the next item's marker. For example, "1. First item that
+// continues\n   onto the next line.\n2. Second item." holds two items.
+//
+// text is the input, as rewritten by any pre-rules, that sents were found in.
+func (p *processor) joinLines(text string, sents []string) []string {
+	if len(sents) < 2 {
+		return sents
+	}
+	offsets := align(text, sents)
+
+	// breaks[i] is the number of line breaks before sents[i], where the
+	// start and end of the text each count as one.
+	// indented[i] is whether sents[i] starts an indented line.
+	n := len(sents)
+	breaks := make([]int, n+1)
+	indented := make([]bool, n)
+	for i := 1; i < n; i++ {
+		gap := text[offsets[i-1][1]:offsets[i][0]]
+		breaks[i] = lineBreaks(gap)
+		if nl := strings.LastIndexAny(gap, "\r\n"); nl >= 0 {
+			indented[i] = nl < len(gap)-1
+		}
+	}
+	breaks[0], breaks[n] = 1, 1
+
+	joined := []string{}
+	for i := 0; i < n; {
+		end := i
+		switch {
+		case breaks[i] > 0 && lineItemRE.MatchString(sents[i]):
+			end = p.itemRun(sents, breaks, indented, i)
+		case breaks[i] > 0:
+			end = p.lineRun(sents, breaks, i)
+		}
+		joined = append(joined, strings.Join(sents[i:end+1], " "))
+		i = end + 1
+	}
+	return joined
+}
+
+// lineRun returns the index of the last of sents in the run of lines that
+// starts with sents[start], or start if there's no such run.
+func (p *processor) lineRun(sents []string, breaks []int, start int) int {
+	end := start
+	for end+1 < len(sents) && breaks[end+1] == 1 && p.isOpenLine(sents, end) &&
+		(p.continues(sents[end]) || end > start && p.continues(sents[end-1])) {
+		end++
+	}
+	for end > start && (!p.hasTerminator(sents[end]) || breaks[end+1] == 0) {
+		end--
+	}
+	return end
+}
+
+// itemRun returns the index of the last of sents in the list item that starts
+// with sents[start], including any indented lines that it wraps onto.
+func (p *processor) itemRun(sents []string, breaks []int, indented []bool, start int) int {
+	end := start
+	for end+1 < len(sents) && breaks[end+1] == 1 && indented[end+1] &&
+		!p.hasTerminator(sents[end]) && !lineItemRE.MatchString(sents[end+1]) {
+		end++
+	}
+	return end
+}
+
+// isOpenLine reports whether the line holding sents[i] continues onto the
+// next line.
+//
+// A line that ends with an abbreviation only continues onto a line that's also
+// open or that starts with a number, such as the date in "Payment due
+// Jan.\n5th of the month." (but not "Payment due Jan.\nPlease remit.").
+func (p *processor) isOpenLine(sents []string, i int) bool {
+	if lineItemRE.MatchString(sents[i]) || lineItemRE.MatchString(sents[i+1]) {
+		return false
+	}
+	if !p.hasTerminator(sents[i]) {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(sents[i+1])
+	return p.endsWithAbbreviation(sents[i]) &&
+		(!p.hasTerminator(sents[i+1]) || unicode.IsDigit(first))
+}
+
+// continues reports whether sent ends with a comma, semicolon, colon, or
+// known abbreviation, any of which suggest that it continues onto the next
+// line.
+func (p *processor) continues(sent string) bool {
+	return strings.ContainsAny(lastRune(sent), ",;:") || p.endsWithAbbreviation(sent)
+}
+
+// hasTerminator reports whether sent ends with terminal punctuation (ignoring
+// any closing quotes or brackets).
+func (p *processor) hasTerminator(sent string) bool {
+	last := lastRune(strings.TrimRight(sent, `"'”’»)]}`))
+	for _, t := range p.terminators {
+		if t == last {
+			return true
+		}
+	}
+	return last != "" && strings.Contains(terminalPunctuation, last)
+}
+
+// endsWithAbbreviation reports whether the last word of sent is one of the
+// language's (or the segmenter's custom) abbreviations followed by a period.
+func (p *processor) endsWithAbbreviation(sent string) bool {
+	fields := strings.Fields(sent)
+	if len(fields) == 0 || !strings.HasSuffix(sent, ".") {
+		return false
+	}
+	word := strings.ToLower(strings.TrimSuffix(fields[len(fields)-1], "."))
+	for _, list := range [][]string{
+		p.abbrReplacer.definition.abbreviations()["abbreviations"],
+		p.abbrReplacer.custom,
+	} {
+		for _, abbr := range list {
+			if strings.TrimSpace(abbr) == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lineBreaks counts the line breaks in gap, treating "\r\n" as one.
+func lineBreaks(gap string) int {
+	return strings.Count(lineEndingReplacer.Replace(gap), "\n")
+}
+
+// lastRune returns the last character of s, or "" if s is empty.
+func lastRune(s string) string {
+	r, size := utf8.DecodeLastRuneInString(s)
+	if size == 0 {
+		return ""
+	}
+	return string(r)
+}
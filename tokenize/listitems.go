@@ -0,0 +1,80 @@
+package tokenize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// listMarkerRE matches an inline list marker such as "(a)", "(iv)", or "(3)".
+var listMarkerRE = regexp.MustCompile(`(?:^|\s)(\(([a-z]|[ivx]+|\d+)\))\s`)
+
+// splitListItems breaks sent into its inline list items, so that
+// "It must (a) work; and (b) be fast." becomes "It must", "(a) work; and",
+// and "(b) be fast.".
+//
+// The markers must form an enumeration of at least two items that starts at
+// "(a)", "(i)", or "(1)", which prevents an isolated parenthetical such as
+// "(x)" from being treated as an item.
+func splitListItems(sent string) []string {
+	locs := listMarkerRE.FindAllStringSubmatchIndex(sent, -1)
+	if len(locs) < 2 {
+		return []string{sent}
+	}
+
+	labels := make([]string, len(locs))
+	for i, loc := range locs {
+		labels[i] = sent[loc[4]:loc[5]]
+	}
+	if !isEnumeration(labels, letterValue) &&
+		!isEnumeration(labels, romanValue) &&
+		!isEnumeration(labels, strconv.Atoi) {
+		return []string{sent}
+	}
+
+	items := []string{}
+	last := 0
+	for _, loc := range locs {
+		if item := strings.TrimSpace(sent[last:loc[2]]); item != "" {
+			items = append(items, item)
+		}
+		last = loc[2]
+	}
+	return append(items, strings.TrimSpace(sent[last:]))
+}
+
+// isEnumeration reports whether labels are numbered 1, 2, 3, ... according
+// to value.
+func isEnumeration(labels []string, value func(string) (int, error)) bool {
+	for i, label := range labels {
+		if n, err := value(label); err != nil || n != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func letterValue(label string) (int, error) {
+	if len(label) != 1 || label[0] < 'a' || label[0] > 'z' {
+		return 0, strconv.ErrSyntax
+	}
+	return int(label[0]-'a') + 1, nil
+}
+
+var romanNumerals = map[byte]int{'i': 1, 'v': 5, 'x': 10}
+
+func romanValue(label string) (int, error) {
+	total := 0
+	for i := 0; i < len(label); i++ {
+		n, ok := romanNumerals[label[i]]
+		if !ok {
+			return 0, strconv.ErrSyntax
+		}
+		if i+1 < len(label) && n < romanNumerals[label[i+1]] {
+			total -= n
+		} else {
+			total += n
+		}
+	}
+	return total, nil
+}
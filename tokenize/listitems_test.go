@@ -0,0 +1,40 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPragmaticListItems(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en", WithListItems(true))
+	util.CheckError(err)
+
+	assert.Equal(t, []string{
+		"The tenant shall",
+		"(a) pay rent on time;",
+		"(b) keep the premises clean; and",
+		"(c) notify the landlord of any damage.",
+	}, tok.Tokenize("The tenant shall (a) pay rent on time; (b) keep the "+
+		"premises clean; and (c) notify the landlord of any damage."))
+
+	assert.Equal(t, []string{
+		"The buyer must",
+		"(i) inspect the goods;",
+		"(ii) sign the receipt;",
+		"(iii) pay the invoice; and",
+		"(iv) file a copy.",
+		"Then it ends.",
+	}, tok.Tokenize("The buyer must (i) inspect the goods; (ii) sign the "+
+		"receipt; (iii) pay the invoice; and (iv) file a copy. Then it ends."))
+
+	// Markers that don't form an enumeration are left alone.
+	for _, text := range []string{
+		"Solve for (x) and (y) in the equation.",
+		"See section (b) and section (a) for details.",
+		"Only one (a) marker here.",
+	} {
+		assert.Equal(t, []string{text}, tok.Tokenize(text))
+	}
+}
@@ -0,0 +1,91 @@
+package tokenize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maskedSpans lists the regexps, in the order they're applied by
+// replaceBetweenQuotes, that find the spans whose punctuation is masked. They
+// are followed by the segmenter's quote regexps.
+var maskedSpans = []*regexp.Regexp{
+	betweenBackticksRE, betweenSingleQuotesRE, betweenDoubleQuotesRE,
+	betweenSquareBracketsRE, betweenParensRE,
+}
+
+// bracketedSpans is the subset of maskedSpans that's still masked when
+// WithQuoteProtection is false.
+var bracketedSpans = maskedSpans[3:]
+
+// A MaskReport describes the parts of a text that are inside of quotes,
+// parentheses, brackets, or inline code and therefore protected from boundary
+// detection.
+type MaskReport struct {
+	// Ranges holds the [start, end) byte offsets of each protected span, in
+	// order. Overlapping spans (e.g., quotes inside of parentheses) are
+	// merged.
+	Ranges [][2]int
+	// Fraction is the share of the text's bytes covered by Ranges.
+	Fraction float64
+}
+
+// Masked reports which parts of text are protected from boundary detection,
+// which is useful for understanding why a document segments oddly.
+//
+// Nothing is masked when WithFastPath is set, and quotes aren't masked when
+// WithQuoteProtection is false.
+func (p *PragmaticSegmenter) Masked(text string) MaskReport {
+	report := MaskReport{Ranges: [][2]int{}}
+	if p.opts.fastPath || len(text) == 0 {
+		return report
+	}
+
+	// Masking is applied to each line separately, so a span can't cross a
+	// line break.
+	patterns := append(append([]*regexp.Regexp{}, maskedSpans...),
+		p.processor.quoteRegexps()...)
+	if p.opts.noQuotes {
+		patterns = bracketedSpans
+	}
+
+	spans := [][2]int{}
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		for _, re := range patterns {
+			for _, loc := range re.FindAllStringIndex(line, -1) {
+				start := offset + loc[0]
+				// The single-quote regexp includes any preceding whitespace.
+				start += len(line[loc[0]:loc[1]]) - len(strings.TrimLeftFunc(
+					line[loc[0]:loc[1]], unicode.IsSpace))
+				spans = append(spans, [2]int{start, offset + loc[1]})
+			}
+		}
+		offset += len(line)
+	}
+
+	sort.Sort(byStart(spans))
+	covered := 0
+	for _, span := range spans {
+		n := len(report.Ranges)
+		if n > 0 && span[0] <= report.Ranges[n-1][1] {
+			if span[1] > report.Ranges[n-1][1] {
+				covered += span[1] - report.Ranges[n-1][1]
+				report.Ranges[n-1][1] = span[1]
+			}
+			continue
+		}
+		report.Ranges = append(report.Ranges, span)
+		covered += span[1] - span[0]
+	}
+
+	report.Fraction = float64(covered) / float64(len(text))
+	return report
+}
+
+type byStart [][2]int
+
+func (s byStart) Len() int           { return len(s) }
+func (s byStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byStart) Less(i, j int) bool { return s[i][0] < s[j][0] }
@@ -0,0 +1,34 @@
+package tokenize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasked(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := `He said "Stop. Now." and left (see p. 5 [fig. 2]). Then 'Go.' he said.`
+	report := tok.Masked(text)
+
+	spans := []string{}
+	for _, r := range report.Ranges {
+		spans = append(spans, text[r[0]:r[1]])
+	}
+	assert.Equal(t, []string{`"Stop. Now."`, "(see p. 5 [fig. 2])", "'Go.'"}, spans)
+
+	covered := len(`"Stop. Now."`) + len("(see p. 5 [fig. 2])") + len("'Go.'")
+	assert.InDelta(t, float64(covered)/float64(len(text)), report.Fraction, 1e-9)
+
+	// Spans can't cross a line break.
+	assert.Empty(t, tok.Masked("A \"quote.\nB.\" C.").Ranges)
+	assert.Zero(t, tok.Masked(strings.Repeat("Plain text. ", 3)).Fraction)
+
+	tok, err = NewPragmaticSegmenter("en", WithFastPath(true))
+	util.CheckError(err)
+	assert.Empty(t, tok.Masked(text).Ranges)
+}
@@ -0,0 +1,96 @@
+package tokenize
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var nonSpaceRE = regexp.MustCompile(`\S+`)
+
+// TokenizeMaxTokens splits text into sentences, further splitting any
+// sentence that contains more than maxTokens tokens (as counted by
+// TreebankWordTokenizer).
+//
+// Oversized sentences are broken at the last clause punctuation (a comma,
+// semicolon, colon, or dash) that fits within the limit, or at whitespace
+// otherwise, and each resulting piece is marked as Forced (with a low
+// Confidence for every boundary but the last). A single word that
+// exceeds the limit on its own is never split. If maxTokens is less than 1,
+// no limit is applied.
+//
+// The pieces of a split sentence keep any quotes that WithStripOuterQuotes
+// would otherwise remove.
+func (p *PragmaticSegmenter) TokenizeMaxTokens(text string, maxTokens int) []Sentence {
+	words := NewTreebankWordTokenizer()
+
+	sents := []Sentence{}
+	for _, sent := range p.sentences(text) {
+		if maxTokens < 1 || len(words.Tokenize(sent.Text)) <= maxTokens {
+			sent.Normalized = p.output(sent.Normalized)
+			sents = append(sents, sent)
+			continue
+		}
+		pieces := splitMaxTokens(sent.Text, maxTokens, words)
+		offsets := align(sent.Text, pieces)
+		normalized := align(sent.Normalized, pieces)
+		for i, piece := range pieces {
+			forced := Sentence{
+				Text:       piece,
+				Normalized: p.rewrite(sent.Normalized[normalized[i][0]:normalized[i][1]]),
+				Runes:      utf8.RuneCountInString(piece),
+				Start:      sent.Start + offsets[i][0],
+				End:        sent.Start + offsets[i][1],
+				Line:       sent.Line + strings.Count(sent.Text[:offsets[i][0]], "\n"),
+				Trailing:   sent.Trailing,
+				Forced:     true,
+				Confidence: sent.Confidence,
+			}
+			if i < len(pieces)-1 {
+				// Only the last piece ends at the original boundary.
+				forced.Trailing = leadingSpace(text[forced.End:])
+				forced.Confidence = forcedConfidence
+			}
+			sents = append(sents, forced)
+		}
+	}
+
+	return sents
+}
+
+// splitMaxTokens greedily breaks sent into pieces of at most maxTokens tokens,
+// preferring to end each piece on clause punctuation.
+func splitMaxTokens(sent string, maxTokens int, words *TreebankWordTokenizer) []string {
+	spans := nonSpaceRE.FindAllStringIndex(sent, -1)
+
+	pieces := []string{}
+	for start := 0; start < len(spans); {
+		end, clause := start+1, 0
+		for next := start + 1; next <= len(spans); next++ {
+			piece := sent[spans[start][0]:spans[next-1][1]]
+			if next > start+1 && len(words.Tokenize(piece)) > maxTokens {
+				break
+			}
+			end = next
+			if endsClause(sent[spans[next-1][0]:spans[next-1][1]]) {
+				clause = next
+			}
+		}
+		if end < len(spans) && clause > start {
+			end = clause
+		}
+		pieces = append(pieces, sent[spans[start][0]:spans[end-1][1]])
+		start = end
+	}
+
+	return pieces
+}
+
+func endsClause(word string) bool {
+	for _, suffix := range []string{",", ";", ":", "—", "–", "-"} {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
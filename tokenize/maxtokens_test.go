@@ -0,0 +1,43 @@
+package tokenize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeMaxTokens(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	long := "The committee reviewed the proposal in detail, discussed its " +
+		"costs and benefits at length, heard from several outside experts " +
+		"who had studied similar programs in other cities; and then, after " +
+		"a short recess, voted to approve the plan with a few minor changes " +
+		"to the budget and the timeline."
+	text := "This is short. " + long + " This is short too."
+
+	words := NewTreebankWordTokenizer()
+	sents := tok.TokenizeMaxTokens(text, 12)
+
+	assert.Equal(t, "This is short.", sents[0].Text)
+	assert.False(t, sents[0].Forced)
+	assert.Equal(t, "This is short too.", sents[len(sents)-1].Text)
+
+	pieces := []string{}
+	for _, sent := range sents[1 : len(sents)-1] {
+		assert.True(t, sent.Forced, sent.Text)
+		assert.True(t, len(words.Tokenize(sent.Text)) <= 12, sent.Text)
+		pieces = append(pieces, sent.Text)
+	}
+	assert.True(t, len(pieces) > 2)
+	assert.Equal(t, long, strings.Join(pieces, " "))
+
+	// We prefer to break after clause punctuation.
+	assert.Equal(t, "The committee reviewed the proposal in detail,", pieces[0])
+
+	// Without a limit, nothing is split.
+	assert.Equal(t, 3, len(tok.TokenizeMaxTokens(text, 0)))
+}
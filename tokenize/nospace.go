@@ -0,0 +1,62 @@
+package tokenize
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jdkato/prose/internal/util"
+)
+
+// noSpaceBoundaryRE matches a terminator that's directly followed by a capital
+// letter, as in "First.Second.".
+var noSpaceBoundaryRE = regexp.MustCompile(`[.!?]\p{Lu}`)
+
+// urlLikeRE matches a token, such as "www.Example.com" or "jane@Example.com",
+// whose periods can't be boundaries.
+var urlLikeRE = regexp.MustCompile(`://|^www\.|@`)
+
+// splitNoSpace inserts a space between a terminator and a capital letter that
+// directly follows it, so that the boundary rules can find the boundary.
+//
+// The terminator must follow a word of at least two letters that isn't a
+// known abbreviation, which leaves initials ("U.S.A."), numbers, and
+// abbreviations ("Mr.Smith") alone. Tokens that look like a URL or an email
+// address are skipped entirely.
+func (p *processor) splitNoSpace(text string) string {
+	abbrs := p.abbrReplacer.definition.abbreviations()["abbreviations"]
+	return nonSpaceRE.ReplaceAllStringFunc(text, func(token string) string {
+		if urlLikeRE.MatchString(token) {
+			return token
+		}
+
+		var b bytes.Buffer
+		last := 0
+		for _, loc := range noSpaceBoundaryRE.FindAllStringIndex(token, -1) {
+			word := trailingLetters(token[:loc[0]])
+			if utf8.RuneCountInString(word) < 2 ||
+				util.StringInSlice(strings.ToLower(word), abbrs) {
+				continue
+			}
+			b.WriteString(token[last : loc[0]+1])
+			b.WriteString(" ")
+			last = loc[0] + 1
+		}
+		b.WriteString(token[last:])
+		return b.String()
+	})
+}
+
+// trailingLetters returns the run of letters at the end of s.
+func trailingLetters(s string) string {
+	idx := strings.LastIndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if idx < 0 {
+		return s
+	}
+	_, size := utf8.DecodeRuneInString(s[idx:])
+	return s[idx+size:]
+}
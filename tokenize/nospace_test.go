@@ -0,0 +1,34 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPragmaticNoSpaceBoundaries(t *testing.T) {
+	text := "First.Second.Third."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{text}, tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithAllowNoSpaceBoundaries(true))
+	util.CheckError(err)
+	for text, expected := range map[string][]string{
+		"First.Second.Third.": {"First.", "Second.", "Third."},
+		"Really?Yes!Great.":   {"Really?", "Yes!", "Great."},
+		"It cost 3.50 dollars.Then it rose.": {
+			"It cost 3.50 dollars.", "Then it rose."},
+		"Visit example.com today.":         {"Visit example.com today."},
+		"Go to www.Example.Com now.":       {"Go to www.Example.Com now."},
+		"See https://Example.Org/A.B now.": {"See https://Example.Org/A.B now."},
+		"Mail jane@Example.Com today.":     {"Mail jane@Example.Com today."},
+		"He lived in the U.S.A. for years.": {
+			"He lived in the U.S.A. for years."},
+		"We met Mr.Smith there.": {"We met Mr.Smith there."},
+	} {
+		assert.Equal(t, expected, tok.Tokenize(text), text)
+	}
+}
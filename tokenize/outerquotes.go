@@ -0,0 +1,59 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// unquote removes the quotation marks that enclose all of sent (e.g.,
+// `"Stop."` or “Stop.”) if the segmenter was created using
+// WithStripOuterQuotes(true). Otherwise, sent is returned as is.
+//
+// A sentence that's nothing but a pair of quotes (e.g., `""`) is also returned
+// as is, rather than as an empty sentence.
+func (p *PragmaticSegmenter) unquote(sent string) string {
+	if !p.opts.stripOuterQuotes {
+		return sent
+	}
+	pairs := append([]QuotePair{{'"', '"'}, {'\'', '\''}}, p.opts.quotePairs...)
+	for _, pair := range pairs {
+		if inner, ok := enclosedBy(sent, pair); ok {
+			if inner = strings.TrimSpace(inner); inner == "" {
+				return sent
+			}
+			return inner
+		}
+	}
+	return sent
+}
+
+// enclosedBy reports whether s starts with pair.Open and ends with the
+// matching pair.Close, returning the text between them if so.
+//
+// A sentence such as `"Go," he said, "now."` starts and ends with quotes, but
+// its first quote is closed before the end, so it isn't enclosed.
+func enclosedBy(s string, pair QuotePair) (string, bool) {
+	first, n := utf8.DecodeRuneInString(s)
+	last, m := utf8.DecodeLastRuneInString(s)
+	if len(s) < n+m || first != pair.Open || last != pair.Close {
+		return "", false
+	}
+
+	inner := s[n : len(s)-m]
+	if pair.Open == pair.Close {
+		return inner, !strings.ContainsRune(inner, pair.Open)
+	}
+	depth := 0
+	for _, r := range inner {
+		switch r {
+		case pair.Open:
+			depth++
+		case pair.Close:
+			depth--
+			if depth < 0 {
+				return "", false
+			}
+		}
+	}
+	return inner, depth == 0
+}
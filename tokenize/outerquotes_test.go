@@ -0,0 +1,72 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripOuterQuotes(t *testing.T) {
+	tests := []struct {
+		lang, text string
+		kept       []string
+		stripped   []string
+	}{
+		{"en", `"Stop right there." He froze.`,
+			[]string{`"Stop right there."`, "He froze."},
+			[]string{"Stop right there.", "He froze."}},
+		{"en", "“Stop right there.” He froze.",
+			[]string{"“Stop right there.”", "He froze."},
+			[]string{"Stop right there.", "He froze."}},
+		{"en", "'Stop right there.' He froze.",
+			[]string{"'Stop right there.'", "He froze."},
+			[]string{"Stop right there.", "He froze."}},
+		{"fr", "«Arrête-toi là.» Il s'est figé.",
+			[]string{"«Arrête-toi là.»", "Il s'est figé."},
+			[]string{"Arrête-toi là.", "Il s'est figé."}},
+		{"de", "„Bleib stehen.“ Er erstarrte.",
+			[]string{"„Bleib stehen.“", "Er erstarrte."},
+			[]string{"Bleib stehen.", "Er erstarrte."}},
+		// Separately closed quotes are never removed.
+		{"en", `"Go," he said, "now."`,
+			[]string{`"Go," he said, "now."`},
+			[]string{`"Go," he said, "now."`}},
+		{"en", "“Go,” he said, “now.”",
+			[]string{"“Go,” he said, “now.”"},
+			[]string{"“Go,” he said, “now.”"}},
+		// Nor are empty ones.
+		{"en", `""`,
+			[]string{`""`},
+			[]string{`""`}},
+		{"en", "“ ” He left.",
+			[]string{"“ ”", "He left."},
+			[]string{"“ ”", "He left."}},
+	}
+	for _, test := range tests {
+		tok, err := NewPragmaticSegmenter(test.lang)
+		util.CheckError(err)
+		assert.Equal(t, test.kept, tok.Tokenize(test.text), test.text)
+
+		tok, err = NewPragmaticSegmenter(test.lang, WithStripOuterQuotes(true))
+		util.CheckError(err)
+		assert.Equal(t, test.stripped, tok.Tokenize(test.text), test.text)
+		assert.Equal(t, test.stripped, normalized(tok.Sentences(test.text)))
+		assert.Equal(t, test.kept, texts(tok.Sentences(test.text)))
+	}
+
+	// Neither are mismatched ones.
+	for _, sent := range []string{"«Stop.”", "“Stop.\"", "“Stop.””", "'Stop.\""} {
+		_, ok := enclosedBy(sent, QuotePair{'“', '”'})
+		assert.False(t, ok, sent)
+		_, ok = enclosedBy(sent, QuotePair{'"', '"'})
+		assert.False(t, ok, sent)
+	}
+
+	tok, err := NewPragmaticSegmenter("en", WithStripOuterQuotes(true))
+	util.CheckError(err)
+	inc := NewIncremental(tok)
+	assert.Equal(t, []string{"Stop right there."},
+		inc.Feed("“Stop right there.” He froze. "))
+	assert.Equal(t, []string{"He froze."}, inc.Flush())
+}
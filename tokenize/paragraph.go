@@ -0,0 +1,26 @@
+package tokenize
+
+import "strings"
+
+// Paragraphs splits text into paragraphs, which are separated by one or more
+// blank lines. Leading and trailing whitespace is removed from each paragraph
+// and empty paragraphs are discarded.
+func Paragraphs(text string) []string {
+	paragraphs := []string{}
+	for _, para := range NewBlanklineTokenizer().Tokenize(text) {
+		if para = strings.TrimSpace(para); para != "" {
+			paragraphs = append(paragraphs, para)
+		}
+	}
+	return paragraphs
+}
+
+// TokenizeParagraphs splits text into paragraphs (see Paragraphs) and then
+// splits each paragraph into sentences.
+func (p *PragmaticSegmenter) TokenizeParagraphs(text string) [][]string {
+	nested := [][]string{}
+	for _, para := range Paragraphs(text) {
+		nested = append(nested, p.Tokenize(para))
+	}
+	return nested
+}
@@ -0,0 +1,31 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraphs(t *testing.T) {
+	text := "  The first paragraph. It has two sentences.\n\n" +
+		"The second one has three. Here is another. And another!\n \n\t\n\n" +
+		"The last paragraph is short.  \n\n  "
+
+	assert.Equal(t, []string{
+		"The first paragraph. It has two sentences.",
+		"The second one has three. Here is another. And another!",
+		"The last paragraph is short.",
+	}, Paragraphs(text))
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	assert.Equal(t, [][]string{
+		{"The first paragraph.", "It has two sentences."},
+		{"The second one has three.", "Here is another.", "And another!"},
+		{"The last paragraph is short."},
+	}, tok.TokenizeParagraphs(text))
+
+	assert.Equal(t, []string{}, Paragraphs(" \n\n "))
+}
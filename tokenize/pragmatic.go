@@ -24,10 +24,13 @@
 package tokenize
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/jdkato/prose/internal/util"
 )
@@ -40,68 +43,661 @@ import (
 // (https://github.com/diasks2/pragmatic_segmenter).
 type PragmaticSegmenter struct {
 	processor languageProcessor
+	opts      *segmenterOptions
+	lang      string
+}
+
+// A SegmenterOption customizes the behavior of a PragmaticSegmenter.
+type SegmenterOption func(opts *segmenterOptions)
+
+type segmenterOptions struct {
+	fastPath           bool
+	noQuotes           bool
+	scanner            bool
+	collapseWhitespace bool
+	keepLineEndings    bool
+	requireTerminator  bool
+	listItems          bool
+	emDashBoundaries   bool
+	blankLines         bool
+	tabs               bool
+	noSpace            bool
+	dashOutput         bool
+	stripOuterQuotes   bool
+	collapseRepeated   bool
+	clean              bool
+	dashTarget         rune
+	lookahead          int
+	minRunes           int
+	minSentenceRunes   int
+	terminators        []rune
+	preRules           []Rule
+	postRules          []Rule
+	quotePairs         []QuotePair
+	abbreviations      []string
+	startValidator     func(next rune) bool
+}
+
+// WithFastPath (default: false) skips the masking of punctuation inside of
+// quotes, parentheses, and brackets.
+//
+// This is significantly faster on clean, well-punctuated text (e.g., generated
+// text), but sentences containing quoted or parenthetical terminators (such as
+// `He said, "Stop." and left.`) will be split incorrectly.
+func WithFastPath(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.fastPath = include
+	}
+}
+
+// WithQuoteProtection (default: true) masks punctuation inside of quotes, so
+// that `He said, "Stop. Go home." Then he left.` is two sentences. If false,
+// terminators inside of quotes split sentences like any other, giving three.
+// A closing quote still stays with the sentence it ends.
+//
+// Unlike WithFastPath, parentheses, brackets, numbers, abbreviations, and
+// ellipses are still handled as usual. This is useful for pre-cleaned text in
+// which quote masking only slows things down.
+func WithQuoteProtection(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.noQuotes = !include
+	}
+}
+
+// WithScanner (default: false) uses a hand-written scanner, rather than the
+// regexp-based rules, to segment plain English text.
+//
+// Text that the scanner can't handle with certainty (e.g., text containing
+// quotes, abbreviations, or numbers) is still segmented by the rules, so the
+// output is the same either way. This option has no effect for other
+// languages or when custom terminators or rules are registered.
+func WithScanner(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.scanner = include
+	}
+}
+
+// WithWhitespaceCollapse (default: false) replaces every run of whitespace
+// inside of an emitted sentence with a single space. For example,
+// "a    b." becomes "a b.".
+//
+// This only affects the output; boundaries are still detected using the
+// original text.
+func WithWhitespaceCollapse(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.collapseWhitespace = include
+	}
+}
+
+// WithPreservedLineEndings (default: false) keeps "\r\n" and "\r" line
+// endings as they are. Otherwise, both are replaced by "\n" before any rules
+// are applied, so that text segments the same way regardless of the platform
+// that it came from.
+func WithPreservedLineEndings(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.keepLineEndings = include
+	}
+}
+
+// WithStripOuterQuotes (default: false) removes the quotation marks that
+// enclose an entire emitted sentence, so that “Stop.” becomes "Stop.". Only a
+// matching pair is removed: straight quotes or one of the language's (or
+// WithQuotePairs') pairs, such as “…” or «…». A sentence that merely starts and
+// ends with quotes, such as `"Go," he said, "now."`, is left as is.
+//
+// Like WithWhitespaceCollapse, this only affects the output.
+func WithStripOuterQuotes(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.stripOuterQuotes = include
+	}
+}
+
+// WithPreserveRepeatedTerminators (default: true) keeps runs of repeated
+// exclamation or question marks, such as "Wow!!!", in the emitted sentences.
+// If false, each run is collapsed to a single mark ("Wow!").
+//
+// Like WithWhitespaceCollapse, this only affects the output: boundaries are
+// still placed after the full run.
+func WithPreserveRepeatedTerminators(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.collapseRepeated = !include
+	}
+}
+
+// WithClean (default: false) applies Clean to each emitted sentence, removing
+// soft hyphens and control characters, repairing double-encoded quotes, and
+// collapsing repeated spaces.
+//
+// Like WithWhitespaceCollapse, this only affects the output. To detect
+// boundaries in the cleaned text instead, call Clean before segmenting.
+func WithClean(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.clean = include
+	}
+}
+
+// WithRequireTerminator (default: false) drops a trailing fragment that
+// doesn't end with terminal punctuation (e.g., "Hello. Wor"), so that only
+// complete sentences are returned. An Incremental keeps such a fragment
+// buffered rather than returning it from Flush.
+func WithRequireTerminator(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.requireTerminator = include
+	}
+}
+
+// WithListItems (default: false) treats the markers of an inline list, such
+// as "(a) ... (b) ..." or "(i) ... (ii) ...", as the start of a new segment.
+// For example,
+//
+//	The tenant shall (a) pay rent; and (b) keep the premises clean.
+//
+// is split into "The tenant shall", "(a) pay rent; and", and "(b) keep the
+// premises clean.".
+func WithListItems(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.listItems = include
+	}
+}
+
+// WithMergeShortFragments (default: 0, disabled) attaches any segment with
+// fewer than minRunes runes (e.g., a stray figure label such as "A.") to the
+// previous sentence, or to the next one if it comes first. For example, with a
+// minRunes of 3,
+//
+//	Figure 3 shows the results.
+//	A.
+//	The values rise.
+//
+// is split into "Figure 3 shows the results. A." and "The values rise.".
+func WithMergeShortFragments(minRunes int) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.minRunes = minRunes
+	}
+}
+
+// WithMinSentenceRunes (default: 0, disabled) skips any boundary that would
+// end a sentence with fewer than minRunes runes, continuing the sentence into
+// the text that follows it instead. For example, with a minRunes of 5,
+// "Hi. I'm Bob. Bye." is split into "Hi. I'm Bob." and "Bye.".
+//
+// Unlike WithMergeShortFragments, which joins a short sentence to the one
+// before it after segmentation, this applies as each boundary is placed. Line
+// breaks are still boundaries.
+func WithMinSentenceRunes(minRunes int) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.minSentenceRunes = minRunes
+	}
+}
+
+// WithEmDashBoundaries (default: false) treats an em dash that joins two
+// clauses, with or without surrounding spaces, as a sentence boundary. For
+// example,
+//
+//	I agreed—he didn't.
+//
+// is split into "I agreed—" and "he didn't.". Hyphens (e.g., "re-enter") are
+// never boundaries.
+func WithEmDashBoundaries(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.emDashBoundaries = include
+	}
+}
+
+// WithBlankLineBoundaries (default: false) treats one or more blank lines as
+// a sentence boundary, even if the preceding line doesn't end with terminal
+// punctuation. For example,
+//
+//	first line
+//
+//	second line
+//
+// is split into "first line" and "second line" rather than being joined into
+// one sentence.
+//
+// Lines are then treated as units, so a sentence that spans several lines
+// without terminal punctuation (e.g., an address or a verse) is no longer
+// joined into one; only the line-based rules apply.
+func WithBlankLineBoundaries(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.blankLines = include
+	}
+}
+
+// WithTabBoundaries (default: false) treats every run of tab characters as a
+// sentence boundary, so that each field of tab-separated text (e.g.,
+// "a\tb\tc") is its own sentence. Spaces are unaffected, as is the lookahead
+// after a terminator (see WithLookahead) when this option isn't set.
+func WithTabBoundaries(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.tabs = include
+	}
+}
+
+// WithAllowNoSpaceBoundaries (default: false) treats a terminator that's
+// directly followed by a capital letter, as in minified or concatenated text
+// ("First.Second.Third."), as a sentence boundary.
+//
+// Decimals, initials ("U.S.A."), known abbreviations ("Mr.Smith"), and tokens
+// that look like a URL or an email address (e.g., "www.Example.com") are left
+// intact.
+func WithAllowNoSpaceBoundaries(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.noSpace = include
+	}
+}
+
+// WithDashNormalization replaces every dash (e.g., a hyphen, en dash, em dash,
+// or minus sign) with target before the text is segmented, so that custom
+// rules only need to handle one kind of dash. A target of 0 (the default)
+// disables normalization.
+//
+// The original dashes are restored in the output unless
+// WithNormalizedDashOutput is also set. Since em dashes are normalized too,
+// WithEmDashBoundaries only has an effect when target is an em dash.
+func WithDashNormalization(target rune) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.dashTarget = target
+	}
+}
+
+// WithNormalizedDashOutput (default: false) keeps the dashes replaced by
+// WithDashNormalization in the emitted sentences.
+func WithNormalizedDashOutput(include bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.dashOutput = include
+	}
+}
+
+// WithSentenceStartValidator registers a predicate that decides whether a
+// sentence may start with the given rune (the first letter or digit after a
+// candidate boundary, ignoring any opening quotes or brackets). A boundary
+// that's followed by an invalid start is removed.
+//
+// For example, a validator of unicode.IsUpper only keeps boundaries that are
+// followed by a capital letter, which joins "See fig. 5 for details." into
+// one sentence.
+//
+// This can be used to support scripts without case or other conventions. Line
+// breaks are always boundaries.
+func WithSentenceStartValidator(valid func(next rune) bool) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.startValidator = valid
+	}
+}
+
+// defaultLookahead is the default number of whitespace characters that may
+// separate a terminator from the start of the next sentence.
+const defaultLookahead = 16
+
+// maxLookahead is the largest window that WithLookahead allows, which is the
+// largest repeat count that the regexp package supports.
+const maxLookahead = 1000
+
+// WithLookahead (default: 16) sets how many whitespace characters (e.g.,
+// "We left at 5 p.m.\t\tThen it rained.") the boundary rules will look
+// through after a terminator to find the start of the next sentence. A window
+// less than 1 only allows a single whitespace character, and a window greater
+// than 1000 is treated as 1000.
+//
+// The window is only consulted where a boundary depends on what follows the
+// terminator, such as after an abbreviation or a closing quote. A terminator
+// that always ends a sentence (e.g., "He left.     She stayed.") does so
+// however much whitespace follows it. Line breaks are always boundaries, so
+// this only affects spaces and tabs.
+func WithLookahead(window int) SegmenterOption {
+	if window > maxLookahead {
+		window = maxLookahead
+	}
+	return func(opts *segmenterOptions) {
+		opts.lookahead = window
+	}
+}
+
+// WithTerminators registers additional characters that end a sentence, in the
+// same way that a period does. For example, WithTerminators([]rune{'|'})
+// splits "a|b|c" into three sentences.
+func WithTerminators(terminators []rune) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.terminators = append(opts.terminators, terminators...)
+	}
+}
+
+// WithQuotePairs replaces the language's quotation marks (e.g., “ and ” for
+// English or „ and “ for German) with the given pairs, so that punctuation
+// inside of, for example, Japanese (「…」) quotes is protected from boundary
+// detection. Straight quotes, parentheses, and brackets are always protected.
+func WithQuotePairs(pairs []QuotePair) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.quotePairs = append([]QuotePair{}, pairs...)
+	}
+}
+
+// WithPreRules registers custom rules that are applied to the input text
+// before it's segmented.
+//
+// Unlike the built-in rules, each custom rule is applied repeatedly until the
+// text stops changing. Rules that can't converge are rejected by
+// NewPragmaticSegmenter where possible (see RuleError).
+func WithPreRules(rules ...Rule) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.preRules = append(opts.preRules, rules...)
+	}
+}
+
+// WithPostRules registers custom rules that are applied to each sentence
+// after segmentation. They're applied in the same way as WithPreRules.
+func WithPostRules(rules ...Rule) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.postRules = append(opts.postRules, rules...)
+	}
+}
+
+// WithAbbreviations registers additional abbreviations (e.g., "approx", "fig",
+// or the multi-word "et seq") whose periods don't end a sentence when followed
+// by a lowercase word or a number.
+//
+// Each entry is normalized by NewPragmaticSegmenter: surrounding whitespace
+// and a trailing period are removed, inner whitespace is reduced to a single
+// space, and it's lowercased, so " Fig. " and "fig" are equivalent. An entry
+// that's empty or contains anything other than letters, digits, spaces, inner
+// periods, apostrophes, or hyphens is rejected (see AbbreviationError).
+func WithAbbreviations(abbrs ...string) SegmenterOption {
+	return func(opts *segmenterOptions) {
+		opts.abbreviations = append(opts.abbreviations, abbrs...)
+	}
+}
+
+// An AbbreviationError describes a custom abbreviation that can't be used.
+type AbbreviationError struct {
+	Abbreviation string
+	Reason       string
+}
+
+func (e *AbbreviationError) Error() string {
+	return fmt.Sprintf("abbreviation %q: %s", e.Abbreviation, e.Reason)
+}
+
+// normalizeAbbreviation returns abbr in the form used for matching (e.g.,
+// "fig" for " Fig. ").
+func normalizeAbbreviation(abbr string) (string, error) {
+	words := strings.Fields(strings.TrimSuffix(strings.TrimSpace(abbr), "."))
+	clean := strings.ToLower(strings.Join(words, " "))
+	if clean == "" {
+		return "", &AbbreviationError{Abbreviation: abbr, Reason: "empty"}
+	}
+	for _, r := range clean {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(".'- ", r) {
+			return "", &AbbreviationError{
+				Abbreviation: abbr, Reason: fmt.Sprintf("invalid character %q", r)}
+		}
+	}
+	if strings.HasPrefix(clean, ".") || strings.Contains(clean, "..") {
+		return "", &AbbreviationError{Abbreviation: abbr, Reason: "empty segment"}
+	}
+	return clean, nil
+}
+
+// maxRuleIterations is the number of times a custom rule may be applied to a
+// single piece of text before we give up on it converging.
+const maxRuleIterations = 100
+
+// A RuleError describes a custom rule that can't be applied safely.
+type RuleError struct {
+	Rule   Rule
+	Reason string
+}
+
+func (e *RuleError) Error() string {
+	pattern := "<nil>"
+	if e.Rule.Pattern != nil {
+		pattern = e.Rule.Pattern.String()
+	}
+	return fmt.Sprintf("rule %q -> %q: %s", pattern, e.Rule.Replacement, e.Reason)
+}
+
+// validate reports the problems with r that we can detect without any input:
+// a missing pattern, a pattern that matches the empty string (and would
+// therefore insert Replacement forever), and a Replacement that the rule
+// itself would rewrite.
+func (r *Rule) validate() error {
+	switch {
+	case r.Pattern == nil:
+		return &RuleError{Rule: *r, Reason: "missing pattern"}
+	case r.Pattern.MatchString(""):
+		return &RuleError{Rule: *r, Reason: "pattern matches the empty string"}
+	case r.Sub(r.Replacement) != r.Replacement:
+		return &RuleError{Rule: *r, Reason: "replacement is rewritten by its own pattern"}
+	}
+	return nil
 }
 
 // NewPragmaticSegmenter creates a new PragmaticSegmenter according to the
 // specified language. If the given language is not supported, an error will be
-// returned.
+// returned. Similarly, a *RuleError is returned for any invalid custom rules
+// and an *AbbreviationError for any invalid custom abbreviations.
 //
 // Languages are specified by their two-character ISO 639-1 code. The supported
-// languages are "en" (English), "es" (Spanish), "fr" (French) ... (WIP)
-func NewPragmaticSegmenter(lang string) (*PragmaticSegmenter, error) {
+// languages are "en" (English), "es" (Spanish), "fr" (French), "tr" (Turkish),
+// "de" (German), "ru" (Russian), "el" (Greek), "th" (Thai) ... (WIP)
+//
+// Each language comes with its own defaults (e.g., "de" protects „…“ quotes),
+// which are applied before opts and can be overridden by them.
+func NewPragmaticSegmenter(lang string, opts ...SegmenterOption) (*PragmaticSegmenter, error) {
 	if p, ok := langToProcessor[lang]; ok {
-		return &PragmaticSegmenter{processor: p}, nil
+		// The language's own defaults (e.g., its quotation marks) come first,
+		// so that each of them can be overridden by opts.
+		base := defaultOptions(p.language())
+		for _, applyOpt := range opts {
+			applyOpt(&base)
+		}
+		abbrs := []string{}
+		for _, abbr := range base.abbreviations {
+			clean, err := normalizeAbbreviation(abbr)
+			if err != nil {
+				return nil, err
+			}
+			abbrs = append(abbrs, clean)
+		}
+		base.abbreviations = abbrs
+		for _, rules := range [][]Rule{base.preRules, base.postRules} {
+			for i := range rules {
+				if err := rules[i].validate(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return &PragmaticSegmenter{
+			processor: p.configure(&base), opts: &base, lang: lang}, nil
 	}
 	return nil, errors.New("unknown language")
 }
 
+// WarmLanguages compiles, ahead of time, the rules that each of the given
+// languages would otherwise compile the first time that they're needed (e.g.,
+// those for each abbreviation). This avoids first-call latency in long-running
+// services that know which languages they'll handle.
+//
+// An error is returned for any unsupported language.
+func WarmLanguages(langs ...string) error {
+	for _, lang := range langs {
+		p, ok := langToProcessor[lang]
+		if !ok {
+			return fmt.Errorf("unknown language: %s", lang)
+		}
+		p.abbrReplacer.warm()
+	}
+	return nil
+}
+
 // Tokenize splits text into sentences.
 func (p *PragmaticSegmenter) Tokenize(text string) []string {
-	return p.processor.process(text)
+	sents, _ := p.Segment(text)
+	return sents
+}
+
+// Segment is like Tokenize, but it also reports custom rules that failed to
+// converge (as a *RuleError).
+//
+// Any such rule is skipped, so the returned sentences are the same as those
+// produced without it.
+func (p *PragmaticSegmenter) Segment(text string) ([]string, error) {
+	sents, _, err := p.segment(text)
+	for i, sent := range sents {
+		sents[i] = p.output(sent)
+	}
+	return sents, err
+}
+
+// segment is like Segment, but it doesn't apply the output-only options (see
+// output). It also returns the sentences as they were before the post-rules
+// were applied, which can still be located in text.
+func (p *PragmaticSegmenter) segment(text string) (sents, located []string, err error) {
+	located, err = p.processor.process(text)
+	sents, postErr := p.applyPostRules(located)
+	if err == nil {
+		err = postErr
+	}
+	if p.opts.requireTerminator && p.isFragment(sents) {
+		sents = sents[:len(sents)-1]
+		located = located[:len(located)-1]
+	}
+	return sents, located, err
+}
+
+// applyPostRules returns a copy of sents with the post-rules applied to each
+// sentence.
+func (p *PragmaticSegmenter) applyPostRules(sents []string) ([]string, error) {
+	var err error
+	rewritten := make([]string, len(sents))
+	for i, sent := range sents {
+		sent, postErr := applyCustomRules(sent, p.opts.postRules)
+		if err == nil {
+			err = postErr
+		}
+		rewritten[i] = sent
+	}
+	return rewritten, err
+}
+
+// output applies the options that only affect how an emitted sentence is
+// written (rather than where it ends) to sent.
+func (p *PragmaticSegmenter) output(sent string) string {
+	return p.unquote(p.rewrite(sent))
+}
+
+// rewrite is like output, but it skips the options that only apply to a
+// complete sentence (i.e., WithStripOuterQuotes).
+func (p *PragmaticSegmenter) rewrite(sent string) string {
+	if p.opts.clean {
+		sent = Clean(sent)
+	}
+	return p.collapseRepeated(sent)
+}
+
+// isFragment reports whether the last of sents lacks terminal punctuation
+// (ignoring any closing quotes or brackets).
+func (p *PragmaticSegmenter) isFragment(sents []string) bool {
+	if len(sents) == 0 {
+		return false
+	}
+	last, _ := utf8.DecodeLastRuneInString(
+		strings.TrimRight(sents[len(sents)-1], `"'”’»)]}`))
+	if strings.ContainsRune(terminalPunctuation, last) {
+		return false
+	}
+	for _, r := range p.opts.terminators {
+		if r == last {
+			return false
+		}
+	}
+	return true
 }
 
 /* Helper functions, regexps, and types */
 
-// A rule associates a regular expression with a replacement string.
-type rule struct {
-	pattern     *regexp.Regexp
-	replacement string
+// A Rule associates a regular expression with a replacement string.
+type Rule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
 }
 
-// sub replaces all occurrences of Pattern with Replacement.
-func (r *rule) sub(text string) string {
-	if !r.pattern.MatchString(text) {
-		return text
+// Sub replaces all occurrences of Pattern's capturing groups with Replacement.
+//
+// The output is built from the match indices in a single pass, so each
+// replacement always lands on the rune boundaries reported by the regexp
+// (even when Replacement and the surrounding text are multibyte).
+func (r *Rule) Sub(text string) string {
+	text, _ = r.sub(text, nil)
+	return text
+}
+
+// sub is like Sub, but it also maps origin, which holds the range of original
+// offsets that each byte offset of text (up to and including len(text))
+// corresponds to, onto the result. Every offset inside of a replacement spans
+// all of the text that it replaced. A nil origin isn't mapped.
+func (r *Rule) sub(text string, origin [][2]int) (string, [][2]int) {
+	matches := r.Pattern.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return text, origin
 	}
 
-	orig := len(text)
-	diff := 0
-	for _, submat := range r.pattern.FindAllStringSubmatchIndex(text, -1) {
-		for idx, mat := range submat {
-			if mat != -1 && idx > 0 && idx%2 == 0 {
-				loc := []int{mat - diff, submat[idx+1] - diff}
-				text = text[:loc[0]] + r.replacement + text[loc[1]:]
-				diff = orig - len(text)
+	var buf bytes.Buffer
+	var mapped [][2]int
+	last := 0
+	for _, submat := range matches {
+		for idx := 2; idx < len(submat); idx += 2 {
+			start, end := submat[idx], submat[idx+1]
+			if start < last {
+				// Either the group didn't participate in the match or it's
+				// nested inside of a group we've already replaced.
+				continue
+			}
+			buf.WriteString(text[last:start])
+			buf.WriteString(r.Replacement)
+			if origin != nil {
+				mapped = append(mapped, origin[last:start]...)
+				for i := 0; i < len(r.Replacement); i++ {
+					if i == 0 {
+						mapped = append(mapped, origin[start])
+					} else {
+						mapped = append(mapped, [2]int{origin[start][0], origin[end][1]})
+					}
+				}
 			}
+			last = end
 		}
 	}
+	buf.WriteString(text[last:])
+	if origin != nil {
+		mapped = append(mapped, origin[last:]...)
+	}
 
-	return text
+	return buf.String(), mapped
+}
+
+// Matches returns the byte offsets of every match of Pattern in text.
+//
+// Each element holds the index pairs for the full match followed by its
+// capturing groups, as reported by regexp's FindAllStringSubmatchIndex. This
+// is useful for inspecting exactly what a Rule will replace.
+func (r *Rule) Matches(text string) [][]int {
+	return r.Pattern.FindAllStringSubmatchIndex(text, -1)
 }
 
 // numbers
 
-var periodBeforeNumberRule = rule{
-	pattern: regexp.MustCompile(`(\.)\d`), replacement: "∯"}
-var numberAfterPeriodBeforeLetterRule = rule{
-	pattern: regexp.MustCompile(`\d(\.)\S`), replacement: "∯"}
-var newLineNumberPeriodSpaceLetterRule = rule{
-	pattern: regexp.MustCompile(`[\n\r]\d(\.)(?:[\s\S]|\))`), replacement: "∯"}
-var startLineNumberPeriodRule = rule{
-	pattern: regexp.MustCompile(`^\d(\.)(?:[\s\S]|\))`), replacement: "∯"}
-var startLineTwoDigitNumberPeriodRule = rule{
-	pattern: regexp.MustCompile(`^\d\d(\.)(?:[\s\S]|\))`), replacement: "∯"}
-var allNumberRules = []rule{
+var periodBeforeNumberRule = Rule{
+	Pattern: regexp.MustCompile(`(\.)\d`), Replacement: "∯"}
+var numberAfterPeriodBeforeLetterRule = Rule{
+	Pattern: regexp.MustCompile(`\d(\.)\S`), Replacement: "∯"}
+var newLineNumberPeriodSpaceLetterRule = Rule{
+	Pattern: regexp.MustCompile(`[\n\r]\d(\.)(?:[\s\S]|\))`), Replacement: "∯"}
+var startLineNumberPeriodRule = Rule{
+	Pattern: regexp.MustCompile(`^\d(\.)(?:[\s\S]|\))`), Replacement: "∯"}
+var startLineTwoDigitNumberPeriodRule = Rule{
+	Pattern: regexp.MustCompile(`^\d\d(\.)(?:[\s\S]|\))`), Replacement: "∯"}
+var allNumberRules = []Rule{
 	periodBeforeNumberRule, numberAfterPeriodBeforeLetterRule,
 	newLineNumberPeriodSpaceLetterRule, startLineNumberPeriodRule,
 	startLineTwoDigitNumberPeriodRule,
@@ -109,108 +705,250 @@ var allNumberRules = []rule{
 
 // common
 
-var cleanRules = []rule{
-	{pattern: regexp.MustCompile(`[^\n]\s(\n)\S`), replacement: ""},
-	{pattern: regexp.MustCompile(`(\n)[a-z]`), replacement: " "},
+var cleanRules = []Rule{
+	{Pattern: regexp.MustCompile(`[^\n]\s(\n)\S`), Replacement: ""},
+	{Pattern: regexp.MustCompile(`(\n)[a-z]`), Replacement: " "},
 }
+var lineEndingReplacer = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+var blankLineRE = regexp.MustCompile(`\s*\n\s*\n\s*`)
+var tabRunRE = regexp.MustCompile(`\t+`)
+
+// bulletRule starts a new line at each bullet glyph that follows other text on
+// the same line, as in text extracted from PDFs ("First point. • Second
+// point"), so that each item is its own sentence. A bullet right after an
+// opening quote or bracket is quoted rather than a list item.
+var bulletRule = Rule{
+	Pattern: regexp.MustCompile(`[^\s"'“‘«(\[{]([ \t]*)[•●▪‣]`), Replacement: "\n"}
+
+// bulletNumberRule protects the period of a number that follows a bullet
+// ("• 9. The first item"), which labels the item rather than ending it.
+var bulletNumberRule = Rule{
+	Pattern: regexp.MustCompile(`[•●▪‣][ \t]*\d{1,3}(\.)[ \t]`), Replacement: "∯"}
 var exclamationWordsRE = regexp.MustCompile(
 	`\s(?:!Xũ|!Kung|ǃʼOǃKung|!Xuun|!Kung-Ekoka|ǃHu|` +
 		`ǃKhung|ǃKu|ǃung|ǃXo|ǃXû|ǃXung|ǃXũ|!Xun|Yahoo!|Y!J|Yum!)\s`)
-var sentenceBoundaryRE = regexp.MustCompile(
-	`\x{ff08}(?:[^\x{ff09}])*\x{ff09}(\s?[A-Z])|` +
-		`\x{300c}(?:[^\x{300d}])*\x{300d}(\s[A-Z])|` +
-		`\((?:[^\)]){2,}\)(\s[A-Z])|` +
-		`'(?:[^'])*[^,]'(\s[A-Z])|` +
-		`"(?:[^"])*[^,]"(\s[A-Z])|` +
-		`“(?:[^”])*[^,]”(\s[A-Z])|` +
-		`\S.*?[。．.！!?？ȸȹ☉☈☇☄]`)
+
+const sentenceBoundaryPattern = `\x{ff08}(?:[^\x{ff09}])*\x{ff09}(\s?\p{Lu})|` +
+	`\x{300c}(?:[^\x{300d}])*\x{300d}(\s\p{Lu})|` +
+	`\((?:[^\)]){2,}\)(\s\p{Lu})|` +
+	`'(?:[^'])*[^,]'(\s\p{Lu})|` +
+	`"(?:[^"])*[^,]"(\s\p{Lu})|` +
+	`“(?:[^”])*[^,]”(\s\p{Lu})|` +
+	`\S.*?[。．.！!?？‽‼⁇⁈⁉⸮ȸȹ☉☈☇☄%s]`
+
+var sentenceBoundaryRE = regexp.MustCompile(fmt.Sprintf(sentenceBoundaryPattern, ""))
 var quotationAtEndOfSentenceRE = regexp.MustCompile(
-	`[!?\.-][\"\'\x{201d}\x{201c}]\s{1}[A-Z]`)
+	`[!?\.‽‼⁇⁈⁉⸮-][\"\'\x{201d}\x{201c}]\s{1}\p{Lu}`)
 var splitSpaceQuotationAtEndOfSentenceRE = regexp.MustCompile(
-	`[!?\.-][\"\'\x{201d}\x{201c}](\s{1})[A-Z]`) // lookahead
+	`[!?\.‽‼⁇⁈⁉⸮-][\"\'\x{201d}\x{201c}](\s{1})\p{Lu}`) // lookahead
 var continuousPunctuationRE = regexp.MustCompile(`\S(!|\?){3,}(?:\s|\z|$)`)
-var possessiveAbbreviationRule = rule{
-	pattern: regexp.MustCompile(`(\.)'s\s|(\.)'s$|(\.)'s\z`), replacement: "∯"}
-var kommanditgesellschaftRule = rule{
-	pattern: regexp.MustCompile(`Co(\.)\sKG`), replacement: "∯"}
-var multiPeriodAbbrevRE = regexp.MustCompile(`(?i)\b[a-z](?:\.[a-z])+[.]`)
+
+// maskContinuousPunctuation masks each run of three or more exclamation or
+// question marks (e.g., "What???"). If the run is followed by whitespace and a
+// capital letter, as in "Wow!!! Great.", its last mark is left to end the
+// sentence.
+func maskContinuousPunctuation(text string) string {
+	var buf bytes.Buffer
+	last := 0
+	for _, loc := range continuousPunctuationRE.FindAllStringIndex(text, -1) {
+		run := strings.TrimRightFunc(text[loc[0]:loc[1]], unicode.IsSpace)
+		tail := text[loc[0]+len(run) : loc[1]]
+
+		end := len(run)
+		next, _ := utf8.DecodeRuneInString(text[loc[1]:])
+		if tail != "" && unicode.IsUpper(next) {
+			end--
+		}
+		buf.WriteString(text[last:loc[0]])
+		buf.WriteString(substitute(substitute(run[:end], "!", "&ᓴ&"), "?", "&ᓷ&"))
+		buf.WriteString(run[end:] + tail)
+		last = loc[1]
+	}
+	buf.WriteString(text[last:])
+	return buf.String()
+}
+
+// mixedTerminatorsRE matches a run of terminators that mixes a period with a
+// question or exclamation mark (e.g., ".?", "?.", or ".!"), which is common in
+// chat and ends a single sentence.
+var mixedTerminatorsRE = regexp.MustCompile(`(?:\.+[?!]|[?!]+\.)[.?!]*`)
+var mixedTerminatorsReplacer = strings.NewReplacer(".", "∯", "?", "&ᓷ&", "!", "&ᓴ&")
+
+var possessiveAbbreviationRule = Rule{
+	Pattern: regexp.MustCompile(`(\.)'s\s|(\.)'s$|(\.)'s\z`), Replacement: "∯"}
+var kommanditgesellschaftRule = Rule{
+	Pattern: regexp.MustCompile(`Co(\.)\sKG`), Replacement: "∯"}
+
+// multiPeriodAbbrevRE matches an abbreviation such as "e.g." or "т.д.", whose
+// final period may already have been masked by one of the listed
+// abbreviations' rules. Go's \b only recognizes ASCII word characters, so the
+// start of the abbreviation is found by looking for a preceding non-word
+// character instead.
+var multiPeriodAbbrevRE = regexp.MustCompile(
+	`(?:^|[^\p{L}\p{N}_])(\p{L}(?:\.\p{L})+[.∯])`)
+
+// An em dash between two words is replaced by an em dash and a line break
+// (which is always a boundary) when WithEmDashBoundaries is set.
+var emDashRule = Rule{
+	Pattern: regexp.MustCompile(`[^\s—]\s?—(\s?)[^\s—]`), Replacement: "\n"}
 
 // var parensBetweenDoubleQuotesRE = regexp.MustCompile(`["”]\s\(.*\)\s["“]`)
 // var betweenDoubleQuotesRE2 = regexp.MustCompile(`(?:[^"])*[^,]"|“(?:[^”])*[^,]”`)
 // var wordWithLeadingApostropheRE = regexp.MustCompile(`\s'(?:[^']|'[a-zA-Z])*'\S`)
 
 // AM/PM
-var upperCasePmRule = rule{
-	pattern: regexp.MustCompile(`P∯M(∯)\s[A-Z]`), replacement: "."}
-var upperCaseAmRule = rule{
-	pattern: regexp.MustCompile(`A∯M(∯)\s[A-Z]`), replacement: "."}
-var lowerCasePmRule = rule{
-	pattern: regexp.MustCompile(`p∯m(∯)\s[A-Z]`), replacement: "."}
-var lowerCaseAmRule = rule{
-	pattern: regexp.MustCompile(`a∯m(∯)\s[A-Z]`), replacement: "."}
-var allAmPmRules = []rule{
+var upperCasePmRule = Rule{
+	Pattern: regexp.MustCompile(`P∯M(∯)\s\p{Lu}`), Replacement: "."}
+var upperCaseAmRule = Rule{
+	Pattern: regexp.MustCompile(`A∯M(∯)\s\p{Lu}`), Replacement: "."}
+var lowerCasePmRule = Rule{
+	Pattern: regexp.MustCompile(`p∯m(∯)\s\p{Lu}`), Replacement: "."}
+var lowerCaseAmRule = Rule{
+	Pattern: regexp.MustCompile(`a∯m(∯)\s\p{Lu}`), Replacement: "."}
+var allAmPmRules = []Rule{
 	upperCasePmRule, upperCaseAmRule, lowerCasePmRule, lowerCaseAmRule}
 
+// A temperature unit (e.g., "77 K." or "350° F.") isn't an initial, so it can
+// end a sentence that's followed by a capital letter.
+var temperatureUnitRule = Rule{
+	Pattern: regexp.MustCompile(`(?:\d|°)\s?[CFK](∯)\s\p{Lu}`), Replacement: "."}
+
 // Searches for periods within an abbreviation and replaces the periods.
-var singleUpperCaseLetterAtStartOfLineRule = rule{
-	pattern: regexp.MustCompile(`^[A-Z](\.)\s`), replacement: "∯"}
-var singleUpperCaseLetterRule = rule{
-	pattern: regexp.MustCompile(`\s[A-Z](\.)\s`), replacement: "∯"}
-var allSingleUpperCaseLetterRules = []rule{
+var singleUpperCaseLetterAtStartOfLineRule = Rule{
+	Pattern: regexp.MustCompile(`^\p{Lu}(\.)\s`), Replacement: "∯"}
+var singleUpperCaseLetterRule = Rule{
+	Pattern: regexp.MustCompile(`\s\p{Lu}(\.)\s`), Replacement: "∯"}
+var allSingleUpperCaseLetterRules = []Rule{
 	singleUpperCaseLetterAtStartOfLineRule, singleUpperCaseLetterRule}
 
 // Searches for ellipses within a string and replaces the periods.
-var threeConsecutiveRule = rule{
-	pattern: regexp.MustCompile(`[^.](\.\.\.)\s+[A-Z]`), replacement: "☏."}
-var fourConsecutiveRule = rule{
-	pattern: regexp.MustCompile(`\S(\.{3})\.\s[A-Z]`), replacement: "ƪ"}
-var threeSpaceRule = rule{
-	pattern: regexp.MustCompile(`((?:\s\.){3}\s)`), replacement: "♟"}
-var fourSpaceRule = rule{
-	pattern: regexp.MustCompile(`[a-z]((?:\.\s){3}\.(?:\z|$|\n))`), replacement: "♝"}
-var otherThreePeriodRule = rule{pattern: regexp.MustCompile(`(\.\.\.)`), replacement: "ƪ"}
-var allEllipsesRules = []rule{
+var threeConsecutiveRule = Rule{
+	Pattern: regexp.MustCompile(`[^.](\.\.\.)\s+\p{Lu}`), Replacement: "☏."}
+var fourConsecutiveRule = Rule{
+	Pattern: regexp.MustCompile(`\S(\.{3})\.\s\p{Lu}`), Replacement: "ƪ"}
+var threeSpaceRule = Rule{
+	Pattern: regexp.MustCompile(`((?:\s\.){3}\s)`), Replacement: "♟"}
+var fourSpaceRule = Rule{
+	Pattern: regexp.MustCompile(`[a-z]((?:\.\s){3}\.(?:\z|$|\n))`), Replacement: "♝"}
+var otherThreePeriodRule = Rule{Pattern: regexp.MustCompile(`(\.\.\.)`), Replacement: "ƪ"}
+var allEllipsesRules = []Rule{
 	threeConsecutiveRule, fourConsecutiveRule, threeSpaceRule, fourSpaceRule,
 	otherThreePeriodRule}
 
 // between_punctuation
-var betweenSingleQuotesRE = regexp.MustCompile(`\s'(?:[^']|'[a-zA-Z])*'`)
+// An apostrophe between two letters (e.g., "don't" or "O'Brien") is part of a
+// word, so it can't close a single-quoted span. An opening quote must follow
+// whitespace or start the text, so that a possessive (e.g., "James'") isn't
+// mistaken for one.
+var betweenSingleQuotesRE = regexp.MustCompile(`(?:^|\s)'(?:[^'\p{L}]|\p{L}+(?:'\p{L}+)*)*'`)
 var betweenDoubleQuotesRE = regexp.MustCompile(`"([^"\\]+|\\{2}|\\.)*"`)
-var betweenArrowQuotesRE = regexp.MustCompile(`«([^»\\]+|\\{2}|\\.)*»`)
-var betweenSmartQuotesRE = regexp.MustCompile(`“([^”\\]+|\\{2}|\\.)*”`)
 var betweenSquareBracketsRE = regexp.MustCompile(`\[([^\]\\]+|\\{2}|\\.)*\]`)
-var betweenParensRE = regexp.MustCompile(`\(([^\(\)\\]+|\\{2}|\\.)*\)`)
+var betweenParensRE = regexp.MustCompile(nestedParensPattern(3))
+var betweenBackticksRE = regexp.MustCompile("```[\\s\\S]*?```|`[^`\n]+`")
+
+// nestedParensPattern returns a pattern that matches a parenthetical
+// containing up to depth levels of parentheses, such as "(see Fig. 3 (top))".
+// Matching the outermost pair is what protects the terminators between the
+// inner and outer closing parentheses.
+func nestedParensPattern(depth int) string {
+	inner := `[^()\\]+|\\{2}|\\.`
+	pattern := `\((?:` + inner + `)*\)`
+	for i := 1; i < depth; i++ {
+		pattern = `\((?:` + inner + `|` + pattern + `)*\)`
+	}
+	return pattern
+}
 
 // subPat replaces all punctuation in the strings that match the regexp pat.
-func subPat(text, mtype string, pat *regexp.Regexp) string {
+func subPat(text, mtype string, pat *regexp.Regexp, masks []punctuationMask) string {
 	canidates := []string{}
 	for _, s := range pat.FindAllString(text, -1) {
 		canidates = append(canidates, strings.TrimSpace(s))
 	}
 	r := punctuationReplacer{
-		matches: canidates, text: text, matchType: mtype}
+		matches: canidates, text: text, matchType: mtype, masks: masks}
 	return r.replace()
 }
 
-// replaceBetweenQuotes replaces punctuation inside quotes.
-func replaceBetweenQuotes(text string) string {
-	text = subPat(text, "single", betweenSingleQuotesRE)
-	text = subPat(text, "double", betweenDoubleQuotesRE)
-	text = subPat(text, "double", betweenSquareBracketsRE)
-	text = subPat(text, "double", betweenParensRE)
-	text = subPat(text, "double", betweenArrowQuotesRE)
-	text = subPat(text, "double", betweenSmartQuotesRE)
+// maskMixedTerminators masks all but the last mark of each run matched by
+// mixedTerminatorsRE, so that the whole run stays with the sentence before it.
+func maskMixedTerminators(text string) string {
+	return mixedTerminatorsRE.ReplaceAllStringFunc(text, func(run string) string {
+		return mixedTerminatorsReplacer.Replace(run[:len(run)-1]) + run[len(run)-1:]
+	})
+}
+
+// A QuotePair is an opening quotation mark and its closing counterpart.
+type QuotePair struct {
+	Open, Close rune
+}
+
+// equalQuotePairs reports whether a and b hold the same pairs, in order.
+func equalQuotePairs(a, b []QuotePair) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newQuoteRegexps creates, for each of pairs, a regexp that matches a
+// quotation enclosed by the pair.
+func newQuoteRegexps(pairs []QuotePair) []*regexp.Regexp {
+	quotes := []*regexp.Regexp{}
+	for _, pair := range pairs {
+		quotes = append(quotes, regexp.MustCompile(fmt.Sprintf(
+			`\x{%x}([^\x{%x}\\]+|\\{2}|\\.)*\x{%x}`,
+			pair.Open, pair.Close, pair.Close)))
+	}
+	return quotes
+}
+
+// replaceBetweenQuotes replaces punctuation inside quotes, including those
+// matched by each of the language's quote regexps.
+func replaceBetweenQuotes(text string, quotes []*regexp.Regexp, masks []punctuationMask) string {
+	// Inline code (e.g., `obj.method()`) is masked just like a quotation.
+	text = subPat(text, "double", betweenBackticksRE, masks)
+	text = subPat(text, "single", betweenSingleQuotesRE, masks)
+	text = subPat(text, "double", betweenDoubleQuotesRE, masks)
+	text = replaceBetweenBrackets(text, masks)
+	for _, quote := range quotes {
+		text = subPat(text, "double", quote, masks)
+	}
 	return text
 }
 
+// terminatedQuoteRE matches a terminator that's immediately followed by a
+// closing quote. When quotes aren't protected, it's masked so that the
+// closingBrackets stage can place the boundary after the quote instead.
+var terminatedQuoteRE = regexp.MustCompile(`[.!?]+["'”’»](?:\s|ȸ|$)`)
+
+// replaceBetweenBrackets replaces punctuation inside of square brackets and
+// parentheses.
+func replaceBetweenBrackets(text string, masks []punctuationMask) string {
+	text = subPat(text, "double", betweenSquareBracketsRE, masks)
+	return subPat(text, "double", betweenParensRE, masks)
+}
+
 // applyRules applies each rule in []rules to text.
-func applyRules(text string, rules []rule) string {
+func applyRules(text string, rules []Rule) string {
 	for _, rule := range rules {
-		text = rule.sub(text)
+		text = rule.Sub(text)
 	}
 	return text
 }
 
+// isSpace reports whether r is whitespace or one of the invisible characters
+// (a zero-width space, word joiner, or byte order mark) that are treated like
+// whitespace at the edges of a sentence.
+func isSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == '\u200b' || r == '\u2060' || r == '\ufeff'
+}
+
 // substitute replaces the substring sub with the string repl.
 func substitute(src, sub, repl string) string {
 	idx := strings.Index(src, sub)
@@ -221,6 +959,107 @@ func substitute(src, sub, repl string) string {
 	return src
 }
 
+// align locates each of sents, in order, within text and returns their
+// [start, end) byte offsets. Since segmentation may normalize whitespace, only
+// non-whitespace characters are compared.
+//
+// Whitespace includes the invisible characters matched by isSpace.
+func align(text string, sents []string) [][2]int {
+	offsets := make([][2]int, 0, len(sents))
+	pos := 0
+	for _, s := range sents {
+		start := -1
+		for _, r := range s {
+			if isSpace(r) {
+				continue
+			}
+			for pos < len(text) {
+				c, size := utf8.DecodeRuneInString(text[pos:])
+				if !isSpace(c) {
+					break
+				}
+				pos += size
+			}
+			if start < 0 {
+				start = pos
+			}
+			if pos < len(text) {
+				_, size := utf8.DecodeRuneInString(text[pos:])
+				pos += size
+			}
+		}
+		if start < 0 {
+			start = pos
+		}
+		offsets = append(offsets, [2]int{start, pos})
+	}
+	return offsets
+}
+
+// newLookaheadRule creates a Rule that reduces a run of up to window spaces
+// and tabs following a terminator (and any closing quotes or brackets) to a
+// single space, since the boundary rules only look one character ahead.
+func newLookaheadRule(terminators []string, window int) *Rule {
+	class := ""
+	for _, t := range terminators {
+		for _, r := range t {
+			class += fmt.Sprintf(`\x{%x}`, r)
+		}
+	}
+	pattern := fmt.Sprintf(
+		`[%s]["'”’»)\]]*([ \t]{2,%d})(?:[^ \t]|\z)`, class, window)
+	return &Rule{Pattern: regexp.MustCompile(pattern), Replacement: " "}
+}
+
+// newClosingBracketRules creates the Rules that mark a boundary after a closing
+// parenthesis, bracket, or quote that's preceded by a (masked) terminator and
+// followed by a capital letter, as in "(He left.) She stayed.".
+//
+// The terminator may be followed by a run of closers, such as "word.')" or
+// "word.\"'", in which case the boundary is placed after the last of them. The
+// boundary is marked with "ȸ", which is removed before the sentences are
+// returned.
+//
+// The closing mark of each of pairs is a closer, too, and a terminator that's
+// followed by closers is masked whether or not an opener was seen.
+func newClosingBracketRules(masks []punctuationMask, pairs []QuotePair) []Rule {
+	sentinels := []string{}
+	for _, mask := range masks {
+		sentinels = append(sentinels, regexp.QuoteMeta(mask.sentinel))
+	}
+	masked := strings.Join(sentinels, "|")
+
+	closers := []string{`\)`, `\]`, `'`, `"`, `”`, `’`, `»`}
+	for _, pair := range pairs {
+		if closer := regexp.QuoteMeta(string(pair.Close)); !util.StringInSlice(closer, closers) {
+			closers = append(closers, closer)
+		}
+	}
+	// Quotes nested inside of another quotation are masked, too.
+	run := append([]string{"&⎋&"}, closers...)
+	for _, mask := range nestedQuoteMasks {
+		run = append(run, mask.sentinel)
+	}
+
+	rules := []Rule{}
+	// A terminator that's followed by closers without an opener (e.g.,
+	// "word.') Next.") isn't masked yet, so it's masked here to keep the
+	// boundary from being placed in front of the closers.
+	for _, mask := range masks {
+		rules = append(rules, Rule{
+			Pattern: regexp.MustCompile(fmt.Sprintf(`(%s)(?:%s)+\s`,
+				regexp.QuoteMeta(mask.glyph), strings.Join(run, "|"))),
+			Replacement: mask.sentinel})
+	}
+	for _, closer := range closers {
+		rules = append(rules, Rule{
+			Pattern: regexp.MustCompile(fmt.Sprintf(`(?:%s)(?:%s)*(%s)\s\p{Lu}`,
+				masked, strings.Join(run, "|"), closer)),
+			Replacement: strings.TrimPrefix(closer, `\`) + "ȸ"})
+	}
+	return rules
+}
+
 // escape
 var escapeRegexReservedCharacters = strings.NewReplacer(
 	`(`, `\(`, `)`, `\)`, `[`, `\[`, `]`, `\]`, `-`, `\-`,
@@ -233,10 +1072,108 @@ var subEscapeRegexReservedCharacters = strings.NewReplacer(
 
 /* punctuation_replacer */
 
+// A punctuationMask associates a punctuation mark with the sentinel that
+// replaces it while it's protected from boundary detection.
+type punctuationMask struct {
+	glyph    string
+	sentinel string
+}
+
+// punctuationMasks lists, in the order they're applied, the marks replaced
+// inside of quotes, parentheses, and brackets. The sentinels are restored by
+// the language definition's subRules.
+var punctuationMasks = []punctuationMask{
+	{".", "∯"},
+	{"。", "&ᓰ&"},
+	{"．", "&ᓱ&"},
+	{"！", "&ᓳ&"},
+	{"!", "&ᓴ&"},
+	{"?", "&ᓷ&"},
+	{"？", "&ᓸ&"},
+	{"‽", "&ᓹ&"},
+	{"‼", "&ᓺ&"},
+	{"⁇", "&ᓻ&"},
+	{"⁈", "&ᓼ&"},
+	{"⁉", "&ᓽ&"},
+	{"⸮", "&ᓾ&"},
+}
+
+// nestedQuoteMasks lists the double quotes replaced inside of single quotes
+// (e.g., 'She said "Stop." and left.'). Unlike the other masks, they're
+// restored after post-processing so that a nested quote can't end a sentence.
+var nestedQuoteMasks = []punctuationMask{
+	{`"`, "&⎌&"},
+	{"“", "&⎍&"},
+	{"”", "&⎎&"},
+}
+
+// customMasks creates a punctuationMask for each user-defined terminator.
+//
+// Their sentinels are taken from Unicode's Private Use Area, so they can't
+// collide with each other or with the terminators themselves.
+func customMasks(terminators []rune) []punctuationMask {
+	masks := []punctuationMask{}
+	for i, r := range terminators {
+		masks = append(masks, punctuationMask{
+			glyph: string(r), sentinel: string(rune(0xE000 + i))})
+	}
+	return masks
+}
+
+// unmask restores the original glyph of each of the given masks.
+func unmask(text string, masks []punctuationMask) string {
+	for _, mask := range masks {
+		text = strings.Replace(text, mask.sentinel, mask.glyph, -1)
+	}
+	return text
+}
+
+// sentinelRunes lists every rune that's used, on its own or as part of a
+// sentinel such as "&ᓷ&", to mark masked punctuation or boundaries.
+const sentinelRunes = "∯∮♬♭ᓰᓱᓳᓴᓷᓸᓹᓺᓻᓼᓽᓾ☉☇☈☄✂⌬ȸȹ⎌⎍⎎⎋ƪ♟♝☏"
+
+// Sentinel runes (and the Private Use Area runes used by customMasks) that
+// occur in the input are escaped to the Supplementary Private Use Area-A,
+// where they can't be mistaken for a sentinel, and restored afterwards.
+const (
+	escapedSentinelBase = 0xF0000
+	escapedPrivateBase  = 0xF1000
+	privateUseStart     = 0xE000
+	privateUseEnd       = 0xF8FF
+)
+
+// escapeSentinels replaces any sentinel runes in text, so that the input's
+// content survives unmasking unchanged.
+func escapeSentinels(text string) string {
+	return strings.Map(func(r rune) rune {
+		if i := strings.IndexRune(sentinelRunes, r); i >= 0 {
+			return escapedSentinelBase + rune(i)
+		} else if r >= privateUseStart && r <= privateUseEnd {
+			return escapedPrivateBase + r - privateUseStart
+		}
+		return r
+	}, text)
+}
+
+// unescapeSentinels reverses escapeSentinels.
+func unescapeSentinels(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= escapedSentinelBase && r < escapedSentinelBase+rune(len(sentinelRunes)):
+			orig, _ := utf8.DecodeRuneInString(sentinelRunes[r-escapedSentinelBase:])
+			return orig
+		case r >= escapedPrivateBase && r <= escapedPrivateBase+privateUseEnd-privateUseStart:
+			return privateUseStart + r - escapedPrivateBase
+		}
+		return r
+	}, text)
+}
+
 type punctuationReplacer struct {
 	matches   []string
 	text      string
 	matchType string
+	masks     []punctuationMask
 }
 
 func (r *punctuationReplacer) replace() string {
@@ -247,16 +1184,17 @@ func (r *punctuationReplacer) replacePunctuation(matches []string) string {
 	r.text = escapeRegexReservedCharacters.Replace(r.text)
 	for _, m := range matches {
 		m = escapeRegexReservedCharacters.Replace(m)
-
-		s := r.sub(m, ".", "∯")
-		sub1 := r.sub(s, "。", "&ᓰ&")
-		sub2 := r.sub(sub1, "．", "&ᓱ&")
-		sub3 := r.sub(sub2, "！", "&ᓳ&")
-		sub4 := r.sub(sub3, "!", "&ᓴ&")
-		sub5 := r.sub(sub4, "?", "&ᓷ&")
-		sub6 := r.sub(sub5, "? ", "&ᓸ&")
+		for _, mask := range r.masks {
+			m = r.sub(m, mask.glyph, mask.sentinel)
+		}
+		// Quotes nested inside of the match are protected content, so they
+		// can't be mistaken for the end of a quoted sentence later on.
 		if r.matchType != "single" {
-			r.sub(sub6, "'", "&⎋&")
+			r.sub(m, "'", "&⎋&")
+		} else {
+			for _, mask := range nestedQuoteMasks {
+				m = r.sub(m, mask.glyph, mask.sentinel)
+			}
 		}
 	}
 	return subEscapeRegexReservedCharacters.Replace(r.text)
@@ -272,16 +1210,19 @@ func (r *punctuationReplacer) sub(content, a, b string) string {
 
 type abbreviationReplacer struct {
 	definition       languageDefinition
-	boundaries       *rule
-	prepositiveCache map[string][]rule
-	numberCache      map[string][]rule
-	periodCache      map[string][]rule
+	custom           []string
+	boundaries       *Rule
+	pronounBounds    *Rule
+	units            *Rule
+	prepositiveCache map[string][]Rule
+	numberCache      map[string][]Rule
+	periodCache      map[string][]Rule
 	searchCache      map[string][]*regexp.Regexp
 }
 
 func newAbbreviationReplacer(lang string) *abbreviationReplacer {
 	var def languageDefinition
-	var bounds *rule
+	var bounds *Rule
 
 	if d, ok := langToDefinition[lang]; ok {
 		def = d
@@ -306,27 +1247,79 @@ func newAbbreviationReplacer(lang string) *abbreviationReplacer {
 
 	if regex != "" {
 		r := regexp.MustCompile(strings.TrimRight(regex, "|"))
-		bounds = &rule{pattern: r, replacement: "."}
+		bounds = &Rule{Pattern: r, Replacement: "."}
 	}
 
 	return &abbreviationReplacer{definition: def, boundaries: bounds,
-		prepositiveCache: make(map[string][]rule),
-		numberCache:      make(map[string][]rule),
-		periodCache:      make(map[string][]rule),
+		pronounBounds:    newPronounBoundaryRule(def),
+		units:            newUnitRule(def),
+		prepositiveCache: make(map[string][]Rule),
+		numberCache:      make(map[string][]Rule),
+		periodCache:      make(map[string][]Rule),
 		searchCache:      make(map[string][]*regexp.Regexp)}
 }
 
+// newPronounBoundaryRule creates a Rule that restores the period of a
+// prepositive abbreviation (e.g., "Dr.") that's followed by a pronoun.
+//
+// A prepositive abbreviation is usually followed by a name ("Dr. Smith"), so
+// its period is always masked; a pronoun, however, can't be part of a name
+// and therefore starts a new sentence ("We called the Dr. He came.").
+func newPronounBoundaryRule(def languageDefinition) *Rule {
+	prepositive := []string{}
+	for _, abbr := range def.abbreviations()["prepositive"] {
+		prepositive = append(prepositive, regexp.QuoteMeta(abbr))
+	}
+	pronouns := def.pronouns()
+	if len(prepositive) == 0 || len(pronouns) == 0 {
+		return nil
+	}
+	pattern := fmt.Sprintf(`(?:^|\s)(?i:%s)(∯)\s(?:%s)\b`,
+		strings.Join(prepositive, "|"), strings.Join(pronouns, "|"))
+	return &Rule{Pattern: regexp.MustCompile(pattern), Replacement: "."}
+}
+
+// newUnitRule creates a Rule that masks the period of a unit of measurement
+// (e.g., "in." or "cm.") that follows a number and precedes a lowercase word,
+// a digit, or clause punctuation ("It is 12 in. long.").
+//
+// Units such as "in" are also ordinary words, so they're only treated as
+// abbreviations in this context. A unit followed by a capital letter still
+// ends the sentence ("It is 12 in. The board is long.").
+func newUnitRule(def languageDefinition) *Rule {
+	units := []string{}
+	for _, unit := range def.abbreviations()["units"] {
+		units = append(units, regexp.QuoteMeta(unit))
+	}
+	if len(units) == 0 {
+		return nil
+	}
+	pattern := fmt.Sprintf(`\d\s?(?i:%s)(\.)(?:[,;:]|\s+%s(?:%s|\d))`,
+		strings.Join(units, "|"), openers, def.lowercase())
+	return &Rule{Pattern: regexp.MustCompile(pattern), Replacement: "∯"}
+}
+
 func (r *abbreviationReplacer) replace(text string) string {
-	text = possessiveAbbreviationRule.sub(text)
-	text = kommanditgesellschaftRule.sub(text)
+	text = possessiveAbbreviationRule.Sub(text)
+	text = kommanditgesellschaftRule.Sub(text)
 	text = applyRules(text, allSingleUpperCaseLetterRules)
 
 	text = r.search(text, r.definition.abbreviations()["abbreviations"])
+	if len(r.custom) > 0 {
+		text = r.search(text, r.custom)
+	}
 	text = r.replaceMultiPeriods(text)
+	if r.units != nil {
+		text = r.units.Sub(text)
+	}
 
 	for _, rule := range allAmPmRules {
-		text = rule.sub(text)
+		text = rule.Sub(text)
+	}
+	if r.pronounBounds != nil {
+		text = r.pronounBounds.Sub(text)
 	}
+	text = temperatureUnitRule.Sub(text)
 
 	return r.replaceBoundary(text)
 }
@@ -341,14 +1334,7 @@ func (r *abbreviationReplacer) search(query string, list []string) string {
 		}
 
 		text := query
-		esc := regexp.QuoteMeta(abbr)
-		if data, ok := r.searchCache[esc]; ok {
-			match, next = data[0], data[1]
-		} else {
-			match = regexp.MustCompile(`(?i)(?:^|\s|\r|\n)` + esc)
-			next = regexp.MustCompile(fmt.Sprintf(`%s (.{1})`, esc))
-			r.searchCache[esc] = []*regexp.Regexp{match, next}
-		}
+		match, next = r.searchRegexps(abbr)
 
 		found := match.FindAllStringSubmatch(text, -1)
 		if len(found) > 0 {
@@ -361,6 +1347,21 @@ func (r *abbreviationReplacer) search(query string, list []string) string {
 	return query
 }
 
+// anySpace matches a single whitespace character, including Unicode spaces
+// such as the no-break space in "No.\u00a042", which `\s` doesn't match.
+const anySpace = `[\s\p{Zs}]`
+
+func (r *abbreviationReplacer) searchRegexps(abbr string) (*regexp.Regexp, *regexp.Regexp) {
+	esc := regexp.QuoteMeta(abbr)
+	if data, ok := r.searchCache[esc]; ok {
+		return data[0], data[1]
+	}
+	match := regexp.MustCompile(`(?i)(?:^|\s|\r|\n)` + esc)
+	next := regexp.MustCompile(fmt.Sprintf(`%s\p{Zs}(.{1})`, esc))
+	r.searchCache[esc] = []*regexp.Regexp{match, next}
+	return match, next
+}
+
 func (r *abbreviationReplacer) scan(text, am string, idx int, chars []string) string {
 	character := ""
 	if len(chars) > idx {
@@ -384,54 +1385,95 @@ func (r *abbreviationReplacer) scan(text, am string, idx int, chars []string) st
 }
 
 func (r *abbreviationReplacer) replacePrepositive(text, abbr string) string {
+	return applyRules(text, r.prepositiveRules(abbr))
+}
+
+func (r *abbreviationReplacer) prepositiveRules(abbr string) []Rule {
 	abbr = strings.ToLower(strings.TrimSpace(abbr))
 	if rules, ok := r.prepositiveCache[abbr]; ok {
-		return applyRules(text, rules)
+		return rules
 	}
-	q1 := fmt.Sprintf(`(?i)\s%s(\.)\s|^%s(\.)\s`, abbr, abbr)
+	q1 := fmt.Sprintf(`(?i)\s%s(\.)%s|^%s(\.)%s`, abbr, anySpace, abbr, anySpace)
 	q2 := fmt.Sprintf(`(?i)\s%s(\.):\d+|^%s(\.):\d+`, abbr, abbr)
-	r1 := rule{pattern: regexp.MustCompile(q1), replacement: "∯"}
-	r2 := rule{pattern: regexp.MustCompile(q2), replacement: "∯"}
-	r.prepositiveCache[abbr] = []rule{r1, r2}
-	return r2.sub(r1.sub(text))
+	r1 := Rule{Pattern: regexp.MustCompile(q1), Replacement: "∯"}
+	r2 := Rule{Pattern: regexp.MustCompile(q2), Replacement: "∯"}
+	r.prepositiveCache[abbr] = []Rule{r1, r2}
+	return r.prepositiveCache[abbr]
 }
 
 func (r *abbreviationReplacer) replaceNumber(text, abbr string) string {
+	return applyRules(text, r.numberRules(abbr))
+}
+
+func (r *abbreviationReplacer) numberRules(abbr string) []Rule {
 	abbr = strings.ToLower(strings.TrimSpace(abbr))
 	if rules, ok := r.numberCache[abbr]; ok {
-		return applyRules(text, rules)
+		return rules
 	}
-	q1 := fmt.Sprintf(`(?i)\s%s(\.)\s\d|^%s(\.)\s\d`, abbr, abbr)
-	q2 := fmt.Sprintf(`(?i)\s%s(\.)\s+\(|^%s(\.)\s+\(`, abbr, abbr)
-	r1 := rule{pattern: regexp.MustCompile(q1), replacement: "∯"}
-	r2 := rule{pattern: regexp.MustCompile(q2), replacement: "∯"}
-	r.numberCache[abbr] = []rule{r1, r2}
-	return r2.sub(r1.sub(text))
+	q1 := fmt.Sprintf(`(?i)\s%s(\.)%s\d|^%s(\.)%s\d`, abbr, anySpace, abbr, anySpace)
+	q2 := fmt.Sprintf(`(?i)\s%s(\.)%s+\(|^%s(\.)%s+\(`, abbr, anySpace, abbr, anySpace)
+	r1 := Rule{Pattern: regexp.MustCompile(q1), Replacement: "∯"}
+	r2 := Rule{Pattern: regexp.MustCompile(q2), Replacement: "∯"}
+	r.numberCache[abbr] = []Rule{r1, r2}
+	return r.numberCache[abbr]
 }
 
 func (r *abbreviationReplacer) replacePeriod(text, abbr string) string {
+	return applyRules(text, r.periodRules(abbr))
+}
+
+func (r *abbreviationReplacer) periodRules(abbr string) []Rule {
 	abbr = strings.TrimSpace(abbr)
 	if rules, ok := r.periodCache[abbr]; ok {
-		return applyRules(text, rules)
+		return rules
 	}
-	q1 := fmt.Sprintf(`\s%s(\.)(?:(?:(?:\.|\:|-|\?)|(?:\s(?:[a-z]|I\s|I'm|I'll|\d))))|^%s(\.)(?:(?:(?:\.|\:|\?)|(?:\s(?:[a-z]|I\s|I'm|I'll|\d))))`, abbr, abbr)
+	// The next word may be preceded by opening quotes or brackets, which we
+	// look through when checking its case.
+	lower := r.definition.lowercase()
+	q1 := fmt.Sprintf(`\s%s(\.)(?:(?:(?:\.|\:|-|\?)|(?:\s%s(?:%s|I\s|I'm|I'll|\d))))|^%s(\.)(?:(?:(?:\.|\:|\?)|(?:\s%s(?:%s|I\s|I'm|I'll|\d))))`, abbr, openers, lower, abbr, openers, lower)
 	q2 := fmt.Sprintf(`\s%s(\.),|^%s(\.),`, abbr, abbr)
-	r1 := rule{pattern: regexp.MustCompile(q1), replacement: "∯"}
-	r2 := rule{pattern: regexp.MustCompile(q2), replacement: "∯"}
-	r.periodCache[abbr] = []rule{r1, r2}
-	return r2.sub(r1.sub(text))
+	// A quotation that ends in clause punctuation (`Inc. "Great place," he
+	// said.`) continues the sentence.
+	q3 := fmt.Sprintf(`\s%s(\.)\s+["“][^"”]*[,;:]["”]|^%s(\.)\s+["“][^"”]*[,;:]["”]`, abbr, abbr)
+	r1 := Rule{Pattern: regexp.MustCompile(q1), Replacement: "∯"}
+	r2 := Rule{Pattern: regexp.MustCompile(q2), Replacement: "∯"}
+	r3 := Rule{Pattern: regexp.MustCompile(q3), Replacement: "∯"}
+	r.periodCache[abbr] = []Rule{r1, r2, r3}
+	return r.periodCache[abbr]
 }
 
+// warm compiles the rules for each of the language's abbreviations, which are
+// otherwise compiled the first time that an abbreviation is found.
+//
+// The period rules depend on how an abbreviation is capitalized in the text,
+// so only its listed (lowercase) form is compiled ahead of time.
+func (r *abbreviationReplacer) warm() {
+	abbrs := r.definition.abbreviations()
+	for _, abbr := range abbrs["abbreviations"] {
+		r.searchRegexps(abbr)
+		r.periodRules(abbr)
+	}
+	for _, abbr := range abbrs["prepositive"] {
+		r.prepositiveRules(abbr)
+	}
+	for _, abbr := range abbrs["number"] {
+		r.numberRules(abbr)
+	}
+}
+
+// openers matches any opening quotes or brackets before a word.
+const openers = `["'“‘(\[]*`
+
 func (r *abbreviationReplacer) replaceBoundary(text string) string {
 	if r.boundaries != nil {
-		return r.boundaries.sub(text)
+		return r.boundaries.Sub(text)
 	}
 	return text
 }
 
 func (r *abbreviationReplacer) replaceMultiPeriods(text string) string {
-	for _, r := range multiPeriodAbbrevRE.FindAllString(text, -1) {
-		text = substitute(text, r, substitute(r, ".", "∯"))
+	for _, m := range multiPeriodAbbrevRE.FindAllStringSubmatch(text, -1) {
+		text = substitute(text, m[1], substitute(m[1], ".", "∯"))
 	}
 	return text
 }
@@ -441,119 +1483,136 @@ func (r *abbreviationReplacer) replaceMultiPeriods(text string) string {
 var langToDefinition = map[string]languageDefinition{
 	"fr": new(frenchDefinition),
 	"es": new(spanishDefinition),
+	"tr": new(turkishDefinition),
+	"de": new(germanDefinition),
+	"ru": new(russianDefinition),
+	"el": new(greekDefinition),
+	"th": new(thaiDefinition),
 }
 
 type languageDefinition interface {
 	punctuation() []string
 	abbreviations() map[string][]string
-	punctRules() map[string]*rule
-	doublePunctRules() []rule
-	exclamationRules() []rule
-	subRules() []rule
-	subEllipsis() []rule
+	punctRules() map[string]*Rule
+	doublePunctRules() []Rule
+	exclamationRules() []Rule
+	subRules() []Rule
+	subEllipsis() []Rule
 	starters() []string
+	pronouns() []string
+	lowercase() string
+	defaults() []SegmenterOption
 }
 
 type commonDefinition struct{}
 
-func (d *commonDefinition) subEllipsis() []rule {
-	return []rule{
-		{pattern: regexp.MustCompile(`(ƪ)`), replacement: "..."},
-		{pattern: regexp.MustCompile(`(♟)`), replacement: " . . . "},
-		{pattern: regexp.MustCompile(`(♝)`), replacement: ". . . ."},
-		{pattern: regexp.MustCompile(`(☏)`), replacement: ".."},
-		{pattern: regexp.MustCompile(`(∮)`), replacement: "."},
+func (d *commonDefinition) subEllipsis() []Rule {
+	return []Rule{
+		{Pattern: regexp.MustCompile(`(ƪ)`), Replacement: "..."},
+		{Pattern: regexp.MustCompile(`(♟)`), Replacement: " . . . "},
+		{Pattern: regexp.MustCompile(`(♝)`), Replacement: ". . . ."},
+		{Pattern: regexp.MustCompile(`(☏)`), Replacement: ".."},
+		{Pattern: regexp.MustCompile(`(∮)`), Replacement: "."},
 	}
 }
 
 func (d *commonDefinition) punctuation() []string {
-	return []string{"。", "．", ".", "！", "!", "?", "？"}
+	return []string{"。", "．", ".", "！", "!", "?", "？", "‽", "‼", "⁇", "⁈", "⁉", "⸮"}
 }
 
 func (d *commonDefinition) abbreviations() map[string][]string {
 	return map[string][]string{
 		"abbreviations": {
-			"adj", "adm", "adv", "al", "ala", "alta", "apr", "arc", "ariz", "ark",
-			"art", "assn", "asst", "attys", "aug", "ave", "bart", "bld", "bldg",
-			"blvd", "brig", "bros", "btw", "cal", "calif", "capt", "cl", "cmdr",
-			"co", "col", "colo", "comdr", "con", "conn", "corp", "cpl", "cres", "ct",
-			"d.phil", "dak", "dec", "del", "dept", "det", "dist", "dr", "dr.phil",
-			"dr.philos", "drs", "e.g", "ens", "esp", "esq", "etc", "exp", "expy",
-			"ext", "feb", "fed", "fla", "ft", "fwy", "fy", "ga", "gen", "gov", "hon",
-			"hosp", "hr", "hway", "hwy", "i.e", "ia", "id", "ida", "ill", "inc",
-			"ind", "ing", "insp", "is", "jan", "jr", "jul", "jun", "kan", "kans",
-			"ken", "ky", "la", "lt", "ltd", "maj", "man", "mar", "mass", "may", "md",
-			"me", "med", "messrs", "mex", "mfg", "mich", "min", "minn", "miss", "mlle",
-			"mm", "mme", "mo", "mont", "mr", "mrs", "ms", "msgr", "mssrs", "mt", "mtn",
-			"neb", "nebr", "nev", "no", "nos", "nov", "nr", "oct", "ok", "okla", "ont",
-			"op", "ord", "ore", "p", "pa", "pd", "pde", "penn", "penna", "pfc", "ph",
-			"ph.d", "pl", "plz", "pp", "prof", "pvt", "que", "rd", "ref", "rep",
-			"reps", "res", "rev", "rt", "sask", "sec", "sen", "sens", "sep", "sept",
-			"sfc", "sgt", "sr", "st", "supt", "surg", "tce", "tenn", "tex", "univ",
-			"usafa", "u.s", "ut", "va", "v", "ver", "vs", "vt", "wash", "wis", "wisc",
-			"wy", "wyo", "yuk"},
+			"adj", "adm", "adv", "al", "ala", "alta", "approx", "apr", "arc", "ariz",
+			"ark", "art", "assn", "asst", "attys", "aug", "ave", "bart", "bld", "bldg",
+			"blvd", "brig", "bros", "btw", "cal", "calif", "capt", "cf", "cl", "cmdr",
+			"co", "col", "colo", "comdr", "con", "conn", "cont'd", "corp", "cpl", "cres",
+			"ct", "d.phil", "dak", "dec", "del", "dept", "det", "dist", "dr", "dr.phil",
+			"dr.philos", "drs", "e.g", "ens", "esp", "esq", "etc", "exp", "expy", "ext",
+			"feb", "fed", "fla", "ft", "fwy", "fy", "ga", "gen", "gov", "hon", "hosp",
+			"hr", "hrs", "hway", "hwy", "i.e", "ia", "id", "ida", "ill", "inc", "ind",
+			"ing", "insp", "is", "jan", "jr", "jul", "jun", "kan", "kans", "ken", "kg",
+			"km", "ky", "la", "lb", "lbs", "lt", "ltd", "maj", "man", "mar", "mass",
+			"may", "md", "me", "med", "messrs", "mex", "mfg", "mi", "mich", "min", "minn",
+			"miss", "mlle", "mm", "mme", "mo", "mont", "mr", "mrs", "ms", "msgr", "mssrs",
+			"mt", "mtn", "neb", "nebr", "nev", "no", "nos", "nov", "nr", "oct", "ok",
+			"okla", "ont", "op", "ord", "ore", "oz", "p", "pa", "pd", "pde", "penn",
+			"penna", "pfc", "ph", "ph.d", "pl", "plz", "pp", "prof", "pvt", "que", "rd",
+			"ref", "rep", "reps", "res", "rev", "rs", "rt", "sask", "sec", "sen", "sens",
+			"sep", "sept", "sfc", "sgt", "sq", "sr", "st", "supt", "surg", "tce", "tenn",
+			"tex", "univ", "usafa", "u.s", "ut", "va", "v", "ver", "viz", "vs", "vt",
+			"wash", "wis", "wisc", "wy", "wyo", "yuk"},
 		"prepositive": {
-			"adm", "attys", "brig", "capt", "cmdr", "col", "cpl", "det", "dr",
+			"adm", "attys", "brig", "capt", "cf", "cmdr", "col", "cpl", "det", "dr",
 			"gen", "gov", "ing", "lt", "maj", "mr", "mrs", "ms", "mt", "messrs",
 			"mssrs", "prof", "ph", "rep", "reps", "rev", "sen", "sens", "sgt",
-			"st", "supt", "v", "vs"},
-		"number": {"art", "ext", "no", "nos", "p", "pp"},
+			"st", "supt", "v", "viz", "vs"},
+		"number": {"art", "ext", "no", "nos", "p", "pp", "rs"},
+		"units": {
+			"cm", "ft", "gal", "in", "kg", "km", "lb", "lbs", "mg", "mi", "ml",
+			"mm", "oz", "pt", "qt", "yd", "yds"},
 	}
 }
 
-func (d *commonDefinition) punctRules() map[string]*rule {
-	return map[string]*rule{
+func (d *commonDefinition) punctRules() map[string]*Rule {
+	return map[string]*Rule{
 		"withMultiplePeriodsAndEmail": {
-			pattern: regexp.MustCompile(`\w(\.)\w`), replacement: "∮"},
-		"geoLocation": {pattern: regexp.MustCompile(`[a-zA-z]°(\.)\s*\d+`),
-			replacement: "∯"},
+			Pattern: regexp.MustCompile(`\w(\.)\w`), Replacement: "∮"},
+		"geoLocation": {Pattern: regexp.MustCompile(`[a-zA-z]°(\.)\s*\d+`),
+			Replacement: "∯"},
 		"questionMarkInQuotation": {
-			pattern: regexp.MustCompile(`(\?)(?:\'|\")`), replacement: "&ᓷ&"},
+			Pattern: regexp.MustCompile(`(\?)(?:\'|\")`), Replacement: "&ᓷ&"},
 		"singleNewLine": {
-			pattern: regexp.MustCompile(`(\s{3,})`), replacement: " "},
+			Pattern: regexp.MustCompile(`(\s{3,})`), Replacement: " "},
 		"extraWhiteSpace": {
-			pattern: regexp.MustCompile(`(\n)`), replacement: "ȹ"},
+			Pattern: regexp.MustCompile(`(\n)`), Replacement: "ȹ"},
 		"subSingleQuote": {
-			pattern: regexp.MustCompile(`(&⎋&)`), replacement: "'"},
+			Pattern: regexp.MustCompile(`(&⎋&)`), Replacement: "'"},
 	}
 }
 
-func (d *commonDefinition) subRules() []rule {
-	return []rule{
-		{pattern: regexp.MustCompile(`(∯)`), replacement: "."},
-		{pattern: regexp.MustCompile(`(♬)`), replacement: "،"},
-		{pattern: regexp.MustCompile(`(♭)`), replacement: ":"},
-		{pattern: regexp.MustCompile(`(&ᓰ&)`), replacement: "。"},
-		{pattern: regexp.MustCompile(`(&ᓱ&)`), replacement: "．"},
-		{pattern: regexp.MustCompile(`(&ᓳ&)`), replacement: "！"},
-		{pattern: regexp.MustCompile(`(&ᓴ&)`), replacement: "!"},
-		{pattern: regexp.MustCompile(`(&ᓷ&)`), replacement: "?"},
-		{pattern: regexp.MustCompile(`(&ᓸ&)`), replacement: "？"},
-		{pattern: regexp.MustCompile(`(☉)`), replacement: "?!"},
-		{pattern: regexp.MustCompile(`(☇)`), replacement: "??"},
-		{pattern: regexp.MustCompile(`(☈)`), replacement: "!?"},
-		{pattern: regexp.MustCompile(`(☄)`), replacement: "!!"},
-		{pattern: regexp.MustCompile(`(&✂&)`), replacement: "("},
-		{pattern: regexp.MustCompile(`(&⌬&)`), replacement: ")"},
-		{pattern: regexp.MustCompile(`(ȸ)`), replacement: ""},
-		{pattern: regexp.MustCompile(`(ȹ)`), replacement: "\n"},
+func (d *commonDefinition) subRules() []Rule {
+	return []Rule{
+		{Pattern: regexp.MustCompile(`(∯)`), Replacement: "."},
+		{Pattern: regexp.MustCompile(`(♬)`), Replacement: "،"},
+		{Pattern: regexp.MustCompile(`(♭)`), Replacement: ":"},
+		{Pattern: regexp.MustCompile(`(&ᓰ&)`), Replacement: "。"},
+		{Pattern: regexp.MustCompile(`(&ᓱ&)`), Replacement: "．"},
+		{Pattern: regexp.MustCompile(`(&ᓳ&)`), Replacement: "！"},
+		{Pattern: regexp.MustCompile(`(&ᓴ&)`), Replacement: "!"},
+		{Pattern: regexp.MustCompile(`(&ᓷ&)`), Replacement: "?"},
+		{Pattern: regexp.MustCompile(`(&ᓸ&)`), Replacement: "？"},
+		{Pattern: regexp.MustCompile(`(&ᓹ&)`), Replacement: "‽"},
+		{Pattern: regexp.MustCompile(`(&ᓺ&)`), Replacement: "‼"},
+		{Pattern: regexp.MustCompile(`(&ᓻ&)`), Replacement: "⁇"},
+		{Pattern: regexp.MustCompile(`(&ᓼ&)`), Replacement: "⁈"},
+		{Pattern: regexp.MustCompile(`(&ᓽ&)`), Replacement: "⁉"},
+		{Pattern: regexp.MustCompile(`(&ᓾ&)`), Replacement: "⸮"},
+		{Pattern: regexp.MustCompile(`(☉)`), Replacement: "?!"},
+		{Pattern: regexp.MustCompile(`(☇)`), Replacement: "??"},
+		{Pattern: regexp.MustCompile(`(☈)`), Replacement: "!?"},
+		{Pattern: regexp.MustCompile(`(☄)`), Replacement: "!!"},
+		{Pattern: regexp.MustCompile(`(&✂&)`), Replacement: "("},
+		{Pattern: regexp.MustCompile(`(&⌬&)`), Replacement: ")"},
+		{Pattern: regexp.MustCompile(`(ȸ)`), Replacement: ""},
+		{Pattern: regexp.MustCompile(`(ȹ)`), Replacement: "\n"},
 	}
 }
 
-func (d *commonDefinition) doublePunctRules() []rule {
-	return []rule{
-		{pattern: regexp.MustCompile(`(\?!)`), replacement: "☉"},
-		{pattern: regexp.MustCompile(`(!\?)`), replacement: "☈"},
-		{pattern: regexp.MustCompile(`(\?\?)`), replacement: "☇"},
-		{pattern: regexp.MustCompile(`(!!)`), replacement: "☄"},
+func (d *commonDefinition) doublePunctRules() []Rule {
+	return []Rule{
+		{Pattern: regexp.MustCompile(`(\?!)`), Replacement: "☉"},
+		{Pattern: regexp.MustCompile(`(!\?)`), Replacement: "☈"},
+		{Pattern: regexp.MustCompile(`(\?\?)`), Replacement: "☇"},
+		{Pattern: regexp.MustCompile(`(!!)`), Replacement: "☄"},
 	}
 }
 
-func (d *commonDefinition) exclamationRules() []rule {
-	return []rule{
-		{pattern: regexp.MustCompile(`(!)(?:\'|\")`), replacement: "&ᓴ&"},
-		{pattern: regexp.MustCompile(`(!)(?:\,\s[a-z])`), replacement: "&ᓴ&"},
-		{pattern: regexp.MustCompile(`(!)(?:\s[a-z])`), replacement: "&ᓴ&"},
+func (d *commonDefinition) exclamationRules() []Rule {
+	return []Rule{
+		{Pattern: regexp.MustCompile(`(!)(?:\'|\")`), Replacement: "&ᓴ&"},
+		{Pattern: regexp.MustCompile(`(!)(?:\,\s[a-z])`), Replacement: "&ᓴ&"},
+		{Pattern: regexp.MustCompile(`(!)(?:\s[a-z])`), Replacement: "&ᓴ&"},
 	}
 }
 
@@ -564,6 +1623,23 @@ func (d *commonDefinition) starters() []string {
 		"When", "Where", "Who", "Why"}
 }
 
+// pronouns returns the subject pronouns that may start a sentence but can't be
+// part of a name.
+func (d *commonDefinition) pronouns() []string {
+	return []string{"He", "I", "It", "She", "They", "We", "You"}
+}
+
+// lowercase returns a regexp that matches the first letter of a word that
+// continues, rather than starts, a sentence.
+func (d *commonDefinition) lowercase() string { return `[a-z]` }
+
+// defaults returns the options that every segmenter for the language starts
+// with, before any options given to NewPragmaticSegmenter are applied.
+func (d *commonDefinition) defaults() []SegmenterOption {
+	return []SegmenterOption{
+		WithQuotePairs([]QuotePair{{'«', '»'}, {'“', '”'}})}
+}
+
 type frenchDefinition struct {
 	commonDefinition
 }
@@ -590,6 +1666,13 @@ func (f *frenchDefinition) abbreviations() map[string][]string {
 
 func (f *frenchDefinition) starters() []string { return []string{} }
 
+func (f *frenchDefinition) pronouns() []string { return []string{} }
+
+func (f *frenchDefinition) defaults() []SegmenterOption {
+	return []SegmenterOption{
+		WithQuotePairs([]QuotePair{{'«', '»'}, {'‹', '›'}, {'“', '”'}})}
+}
+
 type spanishDefinition struct {
 	commonDefinition
 }
@@ -644,52 +1727,629 @@ func (s *spanishDefinition) abbreviations() map[string][]string {
 
 func (s *spanishDefinition) starters() []string { return []string{} }
 
+func (s *spanishDefinition) pronouns() []string { return []string{} }
+
+func (s *spanishDefinition) defaults() []SegmenterOption {
+	return []SegmenterOption{
+		WithQuotePairs([]QuotePair{{'«', '»'}, {'“', '”'}, {'‘', '’'}})}
+}
+
+// turkishDefinition relies on Unicode case categories, rather than ASCII
+// ranges, since Turkish distinguishes between a dotted and dotless i ("İ/i"
+// and "I/ı") and uses several other non-ASCII letters (e.g., "ç" and "ş").
+type turkishDefinition struct {
+	commonDefinition
+}
+
+func (t *turkishDefinition) abbreviations() map[string][]string {
+	return map[string][]string{
+		"abbreviations": {
+			"alb", "apt", "as", "av", "bkz", "bl", "bnb", "böl", "bşk", "c",
+			"cad", "dk", "doç", "dr", "gen", "hz", "ltd", "mah", "md", "mim",
+			"müh", "no", "nö", "ord", "org", "öğr", "örn", "prof", "s",
+			"sn", "sok", "şti", "tel", "tğm", "vb", "vd", "vs", "yrd", "yy",
+			"yzb"},
+		"prepositive": {
+			"alb", "av", "doç", "dr", "gen", "hz", "mim", "müh", "ord", "org",
+			"öğr", "prof", "sn", "tğm", "yrd", "yzb"},
+		"number": {"no", "s", "tel"},
+	}
+}
+
+func (t *turkishDefinition) starters() []string { return []string{} }
+
+func (t *turkishDefinition) pronouns() []string { return []string{} }
+
+func (t *turkishDefinition) lowercase() string { return `\p{Ll}` }
+
+// germanDefinition capitalizes every noun, so an abbreviation that's usually
+// followed by one (e.g., "bzw. Katzen") is prepositive.
+type germanDefinition struct {
+	commonDefinition
+}
+
+func (g *germanDefinition) abbreviations() map[string][]string {
+	return map[string][]string{
+		"abbreviations": {
+			"abb", "abs", "abt", "allg", "anm", "art", "aufl", "bd", "bearb",
+			"bsp", "bspw", "bzgl", "bzw", "ca", "d.h", "dgl", "dr", "dt", "ebd",
+			"einschl", "entspr", "etc", "evtl", "ff", "fr", "geb", "gegr", "gem",
+			"ges", "gest", "ggf", "hr", "hrn", "hrsg", "inkl", "jh", "kap", "max",
+			"min", "mio", "mrd", "nr", "o.ä", "o.g", "prof", "s", "s.o", "s.u",
+			"sog", "std", "str", "tel", "u.a", "u.ä", "usw", "v.a", "v.chr", "vgl",
+			"z.b", "z.t", "zzgl"},
+		"prepositive": {
+			"bspw", "bzgl", "bzw", "ca", "dr", "evtl", "fr", "gem", "ggf", "hr",
+			"hrn", "inkl", "prof", "sog", "vgl", "zzgl"},
+		"number": {"abb", "abs", "art", "bd", "kap", "nr", "s", "tel"},
+	}
+}
+
+func (g *germanDefinition) starters() []string { return []string{} }
+
+func (g *germanDefinition) pronouns() []string { return []string{} }
+
+func (g *germanDefinition) lowercase() string { return `[a-zäöüß]` }
+
+func (g *germanDefinition) defaults() []SegmenterOption {
+	return []SegmenterOption{
+		WithQuotePairs([]QuotePair{{'„', '“'}, {'‚', '‘'}, {'»', '«'}})}
+}
+
+// russianDefinition, like turkishDefinition, relies on Unicode case
+// categories, since an ASCII range would treat every Cyrillic word as the
+// start of a new sentence.
+type russianDefinition struct {
+	commonDefinition
+}
+
+func (r *russianDefinition) abbreviations() map[string][]string {
+	return map[string][]string{
+		"abbreviations": {
+			"акад", "вв", "г", "гг", "гр", "д", "доц", "др", "им", "и.о", "кв",
+			"коп", "млн", "млрд", "напр", "обл", "ок", "пос", "пр", "проф", "рис",
+			"руб", "св", "см", "стр", "т", "т.д", "т.е", "т.к", "т.п", "тов",
+			"тыс", "ул"},
+		"prepositive": {
+			"акад", "гр", "доц", "им", "и.о", "напр", "проф", "св", "т.е", "т.к",
+			"тов", "ул"},
+		"number": {"вв", "гг", "д", "кв", "рис", "см", "стр", "т"},
+	}
+}
+
+func (r *russianDefinition) starters() []string { return []string{} }
+
+func (r *russianDefinition) pronouns() []string { return []string{} }
+
+func (r *russianDefinition) lowercase() string { return `\p{Ll}` }
+
+func (r *russianDefinition) defaults() []SegmenterOption {
+	return []SegmenterOption{
+		WithQuotePairs([]QuotePair{{'«', '»'}, {'„', '“'}})}
+}
+
+// greekDefinition relies on Unicode case categories for the same reason as
+// russianDefinition.
+type greekDefinition struct {
+	commonDefinition
+}
+
+func (g *greekDefinition) abbreviations() map[string][]string {
+	return map[string][]string{
+		"abbreviations": {
+			"αγ", "αρ", "βλ", "δηλ", "δρ", "εκ", "κ", "κα", "καθ", "κ.λπ", "κ.ά",
+			"μ.χ", "οδ", "π.χ", "σελ", "τηλ", "χλμ"},
+		"prepositive": {"αγ", "βλ", "δρ", "κ", "κα", "καθ", "οδ", "π.χ"},
+		"number":      {"αρ", "σελ", "τηλ"},
+	}
+}
+
+func (g *greekDefinition) starters() []string { return []string{} }
+
+func (g *greekDefinition) pronouns() []string { return []string{} }
+
+func (g *greekDefinition) lowercase() string { return `\p{Ll}` }
+
+func (g *greekDefinition) defaults() []SegmenterOption {
+	return []SegmenterOption{
+		WithQuotePairs([]QuotePair{{'«', '»'}, {'“', '”'}})}
+}
+
+// thaiDefinition covers the few abbreviations that Thai writes with periods
+// (titles, eras, and months). Thai rarely ends a sentence with a period, so
+// its text is further split by thaiProcessor.
+type thaiDefinition struct {
+	commonDefinition
+}
+
+func (t *thaiDefinition) abbreviations() map[string][]string {
+	return map[string][]string{
+		"abbreviations": {
+			"ก.ค", "ก.พ", "ก.ย", "ค.ศ", "ดร", "ต.ค", "ธ.ค", "นพ", "ผศ", "พ.ค",
+			"พ.ย", "พ.ศ", "ม.ค", "มิ.ย", "มี.ค", "รศ", "ศ", "ส.ค", "เม.ย"},
+		"prepositive": {"ดร", "นพ", "ผศ", "รศ", "ศ"},
+		"number":      {"ค.ศ", "พ.ศ"},
+	}
+}
+
+func (t *thaiDefinition) starters() []string { return []string{} }
+
+func (t *thaiDefinition) pronouns() []string { return []string{} }
+
+func (t *thaiDefinition) lowercase() string { return `\p{Ll}` }
+
 /* language processors */
 
-var langToProcessor = map[string]languageProcessor{
+var langToProcessor = map[string]*processor{
 	"en": newProcessor("en"),
 	"fr": newProcessor("fr"),
 	"es": newProcessor("es"),
+	"tr": newProcessor("tr"),
+	"de": newProcessor("de"),
+	"ru": newProcessor("ru"),
+	"el": newProcessor("el"),
+	"th": newProcessor("th"),
 }
 
 type languageProcessor interface {
-	process(text string) []string
+	process(text string) ([]string, error)
+	locate(text string, sents []string) [][2]int
+	configure(opts *segmenterOptions) languageProcessor
+	language() languageDefinition
+	quoteRegexps() []*regexp.Regexp
 }
 
 type processor struct {
 	abbrReplacer *abbreviationReplacer
+	opts         *segmenterOptions
+
+	// These are derived from the language definition and opts.
+	lookahead           *Rule
+	dashes              *Rule
+	boundaryRE          *regexp.Regexp
+	closingBracketRules []Rule
+	quotes              []*regexp.Regexp
+	masks               []punctuationMask
+	customMasks         []punctuationMask
+	terminators         []string
 }
 
 func newProcessor(lang string) *processor {
 	r := newAbbreviationReplacer(lang)
-	return &processor{abbrReplacer: r}
+	p := &processor{abbrReplacer: r}
+	opts := defaultOptions(r.definition)
+	return p.with(&opts)
+}
+
+// defaultOptions returns the options that a segmenter for the given language
+// starts with.
+func defaultOptions(def languageDefinition) segmenterOptions {
+	opts := segmenterOptions{lookahead: defaultLookahead}
+	for _, applyOpt := range def.defaults() {
+		applyOpt(&opts)
+	}
+	return opts
+}
+
+// configure returns a copy of p that uses the given options, wrapped in
+// whichever languageProcessor those options (and p's language) call for.
+func (p *processor) configure(opts *segmenterOptions) languageProcessor {
+	configured := p.with(opts)
+
+	_, english := p.abbrReplacer.definition.(*commonDefinition)
+	custom := len(opts.terminators)+len(opts.preRules)+len(opts.postRules) > 0 ||
+		opts.startValidator != nil || opts.dashTarget != 0 || opts.noSpace ||
+		opts.tabs || opts.minRunes > 0 || opts.minSentenceRunes > 0 ||
+		len(opts.abbreviations) > 0
+	if opts.scanner && english && !custom {
+		return newScanner(configured)
+	}
+	if _, thai := p.abbrReplacer.definition.(*thaiDefinition); thai {
+		return &thaiProcessor{fallback: configured}
+	}
+
+	return configured
+}
+
+// with returns a copy of p that uses the given options.
+func (p *processor) with(opts *segmenterOptions) *processor {
+	configured := *p
+	configured.opts = opts
+
+	configured.lookahead = nil
+	configured.dashes = nil
+	if len(opts.abbreviations) > 0 {
+		// The copy shares p's compiled rules, which custom abbreviations are
+		// added to as they're found.
+		replacer := *p.abbrReplacer
+		replacer.custom = opts.abbreviations
+		configured.abbrReplacer = &replacer
+	}
+	if opts.dashTarget != 0 {
+		configured.dashes = newDashRule(opts.dashTarget)
+	}
+	configured.boundaryRE = sentenceBoundaryRE
+	configured.masks = punctuationMasks
+	configured.customMasks = nil
+	configured.terminators = p.abbrReplacer.definition.punctuation()
+	if len(opts.terminators) > 0 {
+		class := ""
+		for _, r := range opts.terminators {
+			class += fmt.Sprintf(`\x{%x}`, r)
+			configured.terminators = append(configured.terminators, string(r))
+		}
+		configured.boundaryRE = regexp.MustCompile(
+			fmt.Sprintf(sentenceBoundaryPattern, class))
+		configured.customMasks = customMasks(opts.terminators)
+		configured.masks = append(
+			append([]punctuationMask{}, configured.customMasks...),
+			punctuationMasks...)
+	}
+
+	// The quote regexps and closing bracket rules for the language's default
+	// quotes are compiled once, by newProcessor, and shared by every
+	// segmenter created from p.
+	shared := p.quotes != nil &&
+		equalQuotePairs(p.opts.quotePairs, opts.quotePairs)
+	if !shared {
+		configured.quotes = newQuoteRegexps(opts.quotePairs)
+	}
+	if !shared || len(p.opts.terminators)+len(opts.terminators) > 0 {
+		configured.closingBracketRules = newClosingBracketRules(
+			configured.masks, opts.quotePairs)
+	}
+
+	switch {
+	case opts.lookahead <= 1:
+	case p.lookahead != nil && p.opts.lookahead == opts.lookahead &&
+		len(p.opts.terminators)+len(opts.terminators) == 0:
+		// The rule for the default options is compiled once, by newProcessor,
+		// and shared by every segmenter created from p.
+		configured.lookahead = p.lookahead
+	default:
+		configured.lookahead = newLookaheadRule(
+			configured.terminators, opts.lookahead)
+	}
+
+	return &configured
+}
+
+func (p *processor) language() languageDefinition {
+	return p.abbrReplacer.definition
+}
+
+func (p *processor) quoteRegexps() []*regexp.Regexp {
+	return p.quotes
 }
 
 func (p *processor) cleanQuotations(text string) string {
 	return substitute(text, "`", "'")
 }
 
-func (p *processor) process(text string) []string {
-	text = p.abbrReplacer.replace(applyRules(text, cleanRules))
-	text = applyRules(text, allNumberRules)
+/* pipeline */
 
-	text = continuousPunctuationRE.ReplaceAllStringFunc(text, func(s string) string {
-		return substitute(substitute(s, "!", "&ᓴ&"), "?", "&ᓷ&")
-	})
+// A stage is a single, named step in the segmentation pipeline.
+//
+// The order in which stages are applied affects the output (for example,
+// abbreviations must be masked before numbers), so each pipeline below is an
+// ordered list rather than a set of calls spread across the processor. The
+// input goes through prepareStages, then the pre-rules (which can report an
+// error, unlike a stage), then textStages; it's then split into sentences
+// using lineStages and boundaryStages, which go through sentenceStages.
+type stage struct {
+	name  string
+	apply func(p *processor, text string) string
+}
+
+// A sentenceStage is a named step that's applied to the sentences once the
+// text has been split. Each is given the original input and the text as
+// rewritten by the pre-rules, in which the sentences were found.
+type sentenceStage struct {
+	name  string
+	apply func(p *processor, input, text string, sents []string) []string
+}
+
+// prepareStages are applied, in order, to the input before the pre-rules.
+var prepareStages = []stage{
+	{"lineEndings", func(p *processor, text string) string {
+		if p.opts.keepLineEndings {
+			return text
+		}
+		return lineEndingReplacer.Replace(text)
+	}},
+	// This happens before the pre-rules so that they can still insert
+	// sentinels (e.g., "∯" to protect a period) on purpose.
+	{"escapeSentinels", func(p *processor, text string) string {
+		return escapeSentinels(text)
+	}},
+	{"dashes", func(p *processor, text string) string {
+		if p.dashes == nil {
+			return text
+		}
+		return p.dashes.Sub(text)
+	}},
+}
+
+// textStages are applied, in order, to the entire input before it's split
+// into lines.
+var textStages = []stage{
+	{"clean", func(p *processor, text string) string {
+		if !p.opts.blankLines {
+			return applyRules(text, cleanRules)
+		}
+		// Each paragraph is cleaned on its own, so that the rules can't join
+		// lines across a blank line.
+		paragraphs := blankLineRE.Split(text, -1)
+		for i, para := range paragraphs {
+			paragraphs[i] = applyRules(para, cleanRules)
+		}
+		return strings.Join(paragraphs, "\n\n")
+	}},
+	{"tabs", func(p *processor, text string) string {
+		if !p.opts.tabs {
+			return text
+		}
+		// This happens after cleaning, which would otherwise join a line
+		// that starts with a lowercase letter to the one before it.
+		return tabRunRE.ReplaceAllString(text, "\n")
+	}},
+	{"bullets", func(p *processor, text string) string {
+		return bulletNumberRule.Sub(bulletRule.Sub(text))
+	}},
+	{"lookahead", func(p *processor, text string) string {
+		if p.lookahead == nil {
+			return text
+		}
+		return p.lookahead.Sub(text)
+	}},
+	{"abbreviations", func(p *processor, text string) string {
+		return p.abbrReplacer.replace(text)
+	}},
+	{"numbers", func(p *processor, text string) string {
+		return applyRules(text, allNumberRules)
+	}},
+	{"noSpaceBoundaries", func(p *processor, text string) string {
+		if !p.opts.noSpace {
+			return text
+		}
+		return p.splitNoSpace(text)
+	}},
+	{"continuousPunctuation", func(p *processor, text string) string {
+		return maskContinuousPunctuation(text)
+	}},
+	{"emails", func(p *processor, text string) string {
+		return p.abbrReplacer.definition.punctRules()["withMultiplePeriodsAndEmail"].Sub(text)
+	}},
+	{"geoLocation", func(p *processor, text string) string {
+		return p.abbrReplacer.definition.punctRules()["geoLocation"].Sub(text)
+	}},
+	{"emDashes", func(p *processor, text string) string {
+		if !p.opts.emDashBoundaries {
+			return text
+		}
+		return emDashRule.Sub(text)
+	}},
+}
+
+// lineStages are applied, in order, to each line of the input.
+var lineStages = []stage{
+	{"singleNewLine", func(p *processor, text string) string {
+		return p.abbrReplacer.definition.punctRules()["singleNewLine"].Sub(text)
+	}},
+	{"ellipses", func(p *processor, text string) string {
+		return applyRules(text, allEllipsesRules)
+	}},
+}
+
+// boundaryStages are applied, in order, to each line that contains
+// punctuation immediately before its sentence boundaries are found.
+var boundaryStages = []stage{
+	{"terminator", func(p *processor, text string) string {
+		if !util.HasAnySuffix(text, p.terminators) {
+			text = text + "ȸ"
+		}
+		return text
+	}},
+	{"exclamationWords", func(p *processor, text string) string {
+		if p.opts.fastPath {
+			return text
+		}
+		return subPat(text, "double", exclamationWordsRE, p.masks)
+	}},
+	{"quotes", func(p *processor, text string) string {
+		if p.opts.fastPath {
+			return text
+		} else if p.opts.noQuotes {
+			text = subPat(text, "double", terminatedQuoteRE, p.masks)
+			return replaceBetweenBrackets(text, p.masks)
+		}
+		return replaceBetweenQuotes(text, p.quotes, p.masks)
+	}},
+	{"closingBrackets", func(p *processor, text string) string {
+		if p.opts.fastPath {
+			return text
+		}
+		return applyRules(text, p.closingBracketRules)
+	}},
+	{"footnotes", func(p *processor, text string) string {
+		return markFootnotes(text)
+	}},
+	{"mixedTerminators", func(p *processor, text string) string {
+		return maskMixedTerminators(text)
+	}},
+	{"doublePunctuation", func(p *processor, text string) string {
+		return applyRules(text, p.abbrReplacer.definition.doublePunctRules())
+	}},
+	{"exclamations", func(p *processor, text string) string {
+		return applyRules(text, p.abbrReplacer.definition.exclamationRules())
+	}},
+	{"questionMarkInQuotation", func(p *processor, text string) string {
+		return p.abbrReplacer.definition.punctRules()["questionMarkInQuotation"].Sub(text)
+	}},
+}
+
+// sentenceStages are applied, in order, to the sentences found in the text.
+var sentenceStages = []sentenceStage{
+	{"listItems", func(p *processor, input, text string, sents []string) []string {
+		if !p.opts.listItems {
+			return sents
+		}
+		items := []string{}
+		for _, sent := range sents {
+			items = append(items, splitListItems(sent)...)
+		}
+		return items
+	}},
+	// Invisible characters (e.g., a zero-width space after a terminator)
+	// aren't part of either neighboring sentence.
+	{"trim", func(p *processor, input, text string, sents []string) []string {
+		trimmed := sents[:0]
+		for _, sent := range sents {
+			if sent = strings.TrimFunc(unescapeSentinels(sent), isSpace); sent != "" {
+				trimmed = append(trimmed, sent)
+			}
+		}
+		return trimmed
+	}},
+	{"joinLines", func(p *processor, input, text string, sents []string) []string {
+		if p.opts.blankLines {
+			return sents
+		}
+		return p.joinLines(text, sents)
+	}},
+	{"shortFragments", func(p *processor, input, text string, sents []string) []string {
+		if p.opts.minRunes <= 0 {
+			return sents
+		}
+		return mergeShortFragments(sents, p.opts.minRunes)
+	}},
+	{"restoreDashes", func(p *processor, input, text string, sents []string) []string {
+		if p.dashes != nil && !p.opts.dashOutput {
+			restoreDashes(input, sents, p.opts.dashTarget)
+		}
+		return sents
+	}},
+	{"collapseWhitespace", func(p *processor, input, text string, sents []string) []string {
+		if p.opts.collapseWhitespace {
+			for i, sent := range sents {
+				sents[i] = strings.Join(strings.Fields(sent), " ")
+			}
+		}
+		return sents
+	}},
+}
+
+// applyStages applies each stage in stages to text.
+func (p *processor) applyStages(text string, stages []stage) string {
+	for _, s := range stages {
+		text = s.apply(p, text)
+	}
+	return text
+}
+
+// prepare applies the rewrites that come before the pre-rules to text.
+func (p *processor) prepare(text string) string {
+	return p.applyStages(text, prepareStages)
+}
+
+// process splits text into sentences. The post-rules aren't applied (see
+// PragmaticSegmenter.segment), so the sentences can be located in text.
+func (p *processor) process(text string) ([]string, error) {
+	input := text
+	text, err := applyCustomRules(p.prepare(text), p.opts.preRules)
+
+	sents := p.split(p.applyStages(text, textStages))
+	for _, s := range sentenceStages {
+		sents = s.apply(p, input, text, sents)
+	}
+	return sents, err
+}
 
-	pRules := p.abbrReplacer.definition.punctRules()
-	text = pRules["withMultiplePeriodsAndEmail"].sub(text)
-	text = pRules["geoLocation"].sub(text)
+// locate returns the [start, end) byte offsets of each of sents, as returned
+// by process, in text.
+//
+// The sentences were found in the text as rewritten by the pre-rules, so the
+// offsets are traced back through any replacements that changed its length.
+func (p *processor) locate(text string, sents []string) [][2]int {
+	if len(p.opts.preRules) == 0 {
+		return align(text, sents)
+	}
 
-	return p.split(text)
+	prepared := p.prepare(text)
+	origin := make([][2]int, len(prepared)+1)
+	for i := range origin {
+		origin[i] = [2]int{i, i}
+	}
+	rewritten, origin, _ := traceCustomRules(prepared, origin, p.opts.preRules)
+
+	// The prepared text has the same non-whitespace characters as text, so
+	// each sentence's span of it can be aligned with text instead.
+	spans := make([]string, len(sents))
+	prev := 0
+	for i, loc := range align(rewritten, sents) {
+		start, end := origin[loc[0]][0], origin[loc[1]][1]
+		if start < prev {
+			// The sentence starts inside of a replacement that the one
+			// before it ended in.
+			start = prev
+		}
+		if end < start {
+			end = start
+		}
+		spans[i] = prepared[start:end]
+		prev = end
+	}
+	return align(text, spans)
+}
+
+// applyCustomRules applies each rule to text until it stops changing.
+//
+// If a rule hasn't converged after maxRuleIterations, its changes are
+// discarded and a *RuleError is returned after the remaining rules have been
+// applied.
+func applyCustomRules(text string, rules []Rule) (string, error) {
+	text, _, err := traceCustomRules(text, nil, rules)
+	return text, err
+}
+
+// traceCustomRules is like applyCustomRules, but it also maps origin onto the
+// result (see Rule.sub).
+func traceCustomRules(text string, origin [][2]int, rules []Rule) (string, [][2]int, error) {
+	var err error
+	for _, r := range rules {
+		result, resultOrigin := text, origin
+		for i := 0; ; i++ {
+			if i == maxRuleIterations {
+				if err == nil {
+					err = &RuleError{Rule: r, Reason: fmt.Sprintf(
+						"no fixed point after %d iterations", maxRuleIterations)}
+				}
+				result, resultOrigin = text, origin
+				break
+			}
+			next, nextOrigin := r.sub(result, resultOrigin)
+			if next == result {
+				break
+			}
+			result, resultOrigin = next, nextOrigin
+		}
+		text, origin = result, resultOrigin
+	}
+	return text, origin, err
 }
 
 func (p *processor) split(text string) []string {
 	segments := []string{}
-	nLineRule := p.abbrReplacer.definition.punctRules()["singleNewLine"]
 	for _, segment := range strings.Split(text, "\n") {
-		segment = nLineRule.sub(segment)
-		segment = applyRules(segment, allEllipsesRules)
+		if strings.TrimSpace(segment) == "" {
+			// Blank lines only separate sentences.
+			continue
+		}
+		// Several of the line rules (e.g., for ellipses) look for the end of
+		// the line, so trailing whitespace (including the "\r" of a "\r\n"
+		// line ending) is removed to keep it from changing their results.
+		segment = strings.TrimRightFunc(segment, unicode.IsSpace)
+		segment = p.applyStages(segment, lineStages)
 		segments = append(segments, p.checkPunct(segment)...)
 	}
 	return segments
@@ -698,8 +2358,7 @@ func (p *processor) split(text string) []string {
 func (p *processor) checkPunct(text string) []string {
 	segments := []string{}
 
-	chars := p.abbrReplacer.definition.punctuation()
-	if util.ContainsAny(text, chars) {
+	if util.ContainsAny(text, p.terminators) {
 		segments = append(segments, p.processText(text)...)
 	} else {
 		segments = append(segments, text)
@@ -709,23 +2368,92 @@ func (p *processor) checkPunct(text string) []string {
 	singq := p.abbrReplacer.definition.punctRules()["subSingleQuote"]
 	for _, segment := range segments {
 		segment = applyRules(segment, p.abbrReplacer.definition.subRules())
-		segment = singq.sub(segment)
-		sentences = append(sentences, p.postProcess(segment)...)
+		segment = unmask(singq.Sub(segment), p.customMasks)
+		for _, sent := range p.postProcess(segment) {
+			sentences = append(sentences, unmask(sent, nestedQuoteMasks))
+		}
 	}
 	return sentences
 }
 
 func (p *processor) processText(text string) []string {
-	pRules := p.abbrReplacer.definition.punctRules()
-	if !util.HasAnySuffix(text, p.abbrReplacer.definition.punctuation()) {
-		text = text + "ȸ"
-	}
-	text = subPat(text, "double", exclamationWordsRE)
-	text = replaceBetweenQuotes(text)
-	text = applyRules(text, p.abbrReplacer.definition.doublePunctRules())
-	text = applyRules(text, p.abbrReplacer.definition.exclamationRules())
-	text = pRules["questionMarkInQuotation"].sub(text)
-	return sentenceBoundaryRE.FindAllString(text, -1)
+	text = p.applyStages(text, boundaryStages)
+
+	// Text that isn't part of any match (e.g., a lone terminator, which the
+	// boundary regexp can't start a match with) is attached to a neighboring
+	// segment rather than being dropped.
+	segments := []string{}
+	last := 0
+	for last < len(text) {
+		loc := p.boundaryRE.FindStringSubmatchIndex(text[last:])
+		if loc == nil {
+			break
+		}
+		start, end := last+loc[0], last+loc[1]
+		// A capturing group in the boundary regexp stands in for a lookahead
+		// (e.g., the capital letter after a closing quote), so the text that
+		// it matched belongs to the next segment.
+		for group := 2; group < len(loc); group += 2 {
+			if loc[group] >= 0 {
+				end = last + loc[group]
+				break
+			}
+		}
+
+		// A closing quote or bracket after the final terminator (e.g., the
+		// ")" of "See it.)") ends the last sentence rather than starting one.
+		dangling := strings.Trim(text[start:end], `ȸ"'”’»)]}`) == "" &&
+			strings.TrimSpace(text[end:]) == ""
+		if n := len(segments); n > 0 && (dangling || p.tooShort(segments[n-1]) ||
+			!p.validStart(text[start:end])) {
+			segments[n-1] += text[last:end]
+			last = end
+			continue
+		}
+		if gap := text[last:start]; strings.TrimSpace(gap) != "" {
+			if n := len(segments); n > 0 {
+				segments[n-1] += gap
+			} else {
+				start = last
+			}
+		}
+		segments = append(segments, text[start:end])
+		last = end
+	}
+
+	if gap := text[last:]; strings.TrimSpace(gap) != "" {
+		if n := len(segments); n > 0 {
+			segments[n-1] += gap
+		} else {
+			segments = append(segments, gap)
+		}
+	}
+	return segments
+}
+
+// tooShort reports whether segment has fewer runes than the minimum set by
+// WithMinSentenceRunes (if any), ignoring sentinels.
+func (p *processor) tooShort(segment string) bool {
+	if p.opts.minSentenceRunes <= 0 {
+		return false
+	}
+	segment = applyRules(segment, p.abbrReplacer.definition.subRules())
+	segment = strings.TrimSpace(unmask(segment, p.customMasks))
+	return utf8.RuneCountInString(segment) < p.opts.minSentenceRunes
+}
+
+// validStart reports whether segment may start a new sentence according to
+// the validator registered by WithSentenceStartValidator (if any).
+func (p *processor) validStart(segment string) bool {
+	if p.opts.startValidator == nil {
+		return true
+	}
+	for _, r := range segment {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return p.opts.startValidator(r)
+		}
+	}
+	return true
 }
 
 var earlyExit = regexp.MustCompile(`\A[a-zA-Z]*\z`)
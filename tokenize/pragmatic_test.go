@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
 )
 
 type goldenRule struct {
@@ -18,9 +23,79 @@ type goldenRule struct {
 func TestPragmaticRulesEn(t *testing.T) { testLang("en", t) }
 func TestPragmaticRulesFr(t *testing.T) { testLang("fr", t) }
 func TestPragmaticRulesEs(t *testing.T) { testLang("es", t) }
+func TestPragmaticRulesTr(t *testing.T) { testLang("tr", t) }
+func TestPragmaticRulesRu(t *testing.T) { testLang("ru", t) }
+func TestPragmaticRulesEl(t *testing.T) { testLang("el", t) }
+func TestPragmaticRulesTh(t *testing.T) { testLang("th", t) }
 
 func BenchmarkPragmaticRulesEn(b *testing.B) { benchmarkLang("en", b) }
 
+var quoteFreeText = strings.Repeat("I can see Mt. Fuji from here. "+
+	"St. Michael's Church is on 5th st. near the light. "+
+	"She has $100.00 in her bag. What is your name? My name is Jonas. ", 20)
+
+func BenchmarkPragmaticFullPath(b *testing.B) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	for n := 0; n < b.N; n++ {
+		tok.Tokenize(quoteFreeText)
+	}
+}
+
+func BenchmarkPragmaticFastPath(b *testing.B) {
+	tok, err := NewPragmaticSegmenter("en", WithFastPath(true))
+	util.CheckError(err)
+	for n := 0; n < b.N; n++ {
+		tok.Tokenize(quoteFreeText)
+	}
+}
+
+func TestPragmaticFastPath(t *testing.T) {
+	full, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	fast, err := NewPragmaticSegmenter("en", WithFastPath(true))
+	util.CheckError(err)
+
+	assert.Equal(t, full.Tokenize(quoteFreeText), fast.Tokenize(quoteFreeText))
+
+	text := "She turned to him, \"This is great.\" she said."
+	assert.Equal(t, []string{text}, full.Tokenize(text))
+	assert.Equal(t, 2, len(fast.Tokenize(text)))
+}
+
+func BenchmarkPragmaticQuoteProtection(b *testing.B) {
+	tok, err := NewPragmaticSegmenter("en", WithQuoteProtection(false))
+	util.CheckError(err)
+	for n := 0; n < b.N; n++ {
+		tok.Tokenize(quoteFreeText)
+	}
+}
+
+func TestPragmaticQuoteProtection(t *testing.T) {
+	full, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	bare, err := NewPragmaticSegmenter("en", WithQuoteProtection(false))
+	util.CheckError(err)
+
+	assert.Equal(t, full.Tokenize(quoteFreeText), bare.Tokenize(quoteFreeText))
+
+	text := "He said, \"Stop. Go home.\" Then he left."
+	assert.Equal(t, []string{
+		"He said, \"Stop. Go home.\"",
+		"Then he left."}, full.Tokenize(text))
+	assert.Equal(t, []string{
+		"He said, \"Stop.",
+		"Go home.\"",
+		"Then he left."}, bare.Tokenize(text))
+
+	// Parentheses, numbers, abbreviations, and ellipses are still protected.
+	text = "He paid $3.50 to Mr. Smith (who left. Or not.) at 5 p.m. today... Then went home."
+	assert.Equal(t, full.Tokenize(text), bare.Tokenize(text))
+	assert.Equal(t, 0, len(bare.Masked("\"Stop.\" he said.").Ranges))
+}
+
 func benchmarkLang(lang string, b *testing.B) {
 	tests := make([]goldenRule, 0)
 	f := fmt.Sprintf("golden_rules_%s.json", lang)
@@ -51,6 +126,68 @@ func testLang(lang string, t *testing.T) {
 	}
 }
 
+// TestPragmaticReference compares the segmenter against the reference
+// golden rules shared by the original Ruby library and its ports (e.g.,
+// pySBD), reporting how many of them it doesn't match.
+//
+// A rule with a "limitation" is a known difference: its mismatch is only
+// logged, and it's an error for it to match (so that the note is removed).
+func TestPragmaticReference(t *testing.T) {
+	rules := []struct {
+		goldenRule
+		Limitation string
+	}{}
+	cases := util.ReadDataFile(filepath.Join(testdata, "reference_en.json"))
+	util.CheckError(json.Unmarshal(cases, &rules))
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	mismatches := 0
+	for _, rule := range rules {
+		actual := tok.Tokenize(rule.Input)
+		matched := assert.ObjectsAreEqual(rule.Output, actual)
+		switch {
+		case !matched && rule.Limitation != "":
+			mismatches++
+			t.Logf("%s (known limitation: %s)\nActual: %q", rule.Name,
+				rule.Limitation, actual)
+		case !matched:
+			mismatches++
+			t.Errorf("%s\nActual: %q\nExpected: %q", rule.Name, actual,
+				rule.Output)
+		case rule.Limitation != "":
+			t.Errorf("%s now matches; remove its limitation", rule.Name)
+		}
+	}
+	t.Logf("%d of %d reference rules differ (%.1f%%)", mismatches, len(rules),
+		100*float64(mismatches)/float64(len(rules)))
+}
+
+// TestPragmaticFixtures runs every fixture in testdata/sentences/<lang>/: an
+// input file, <name>.txt, and a JSON array of its expected sentences,
+// <name>.json.
+func TestPragmaticFixtures(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join(testdata, "sentences", "*", "*.txt"))
+	util.CheckError(err)
+	assert.NotEmpty(t, inputs)
+
+	for _, input := range inputs {
+		lang := filepath.Base(filepath.Dir(input))
+		tok, err := NewPragmaticSegmenter(lang)
+		util.CheckError(err)
+
+		expected := []string{}
+		golden := strings.TrimSuffix(input, ".txt") + ".json"
+		util.CheckError(json.Unmarshal(util.ReadDataFile(golden), &expected))
+
+		// Editors usually add a final newline, which isn't part of the
+		// fixture itself.
+		text := strings.TrimRight(string(util.ReadDataFile(input)), "\n")
+		compare(t, input, text, expected, tok)
+	}
+}
+
 func compare(t *testing.T, test, actualText string, expected []string, tok *PragmaticSegmenter) bool {
 	actual := tok.Tokenize(actualText)
 	if len(actual) != len(expected) {
@@ -70,3 +207,588 @@ func compare(t *testing.T, test, actualText string, expected []string, tok *Prag
 	}
 	return true
 }
+
+func stageNames(stages []stage) []string {
+	names := []string{}
+	for _, s := range stages {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+func TestPipelineOrder(t *testing.T) {
+	assert.Equal(t, []string{
+		"lineEndings", "escapeSentinels", "dashes"}, stageNames(prepareStages))
+	assert.Equal(t, []string{
+		"clean", "tabs", "bullets", "lookahead", "abbreviations", "numbers", "noSpaceBoundaries",
+		"continuousPunctuation", "emails", "geoLocation", "emDashes"},
+		stageNames(textStages))
+	assert.Equal(t, []string{
+		"singleNewLine", "ellipses"}, stageNames(lineStages))
+	assert.Equal(t, []string{
+		"terminator", "exclamationWords", "quotes", "closingBrackets",
+		"footnotes", "mixedTerminators", "doublePunctuation",
+		"exclamations", "questionMarkInQuotation"}, stageNames(boundaryStages))
+
+	names := []string{}
+	for _, s := range sentenceStages {
+		names = append(names, s.name)
+	}
+	assert.Equal(t, []string{
+		"listItems", "trim", "joinLines", "shortFragments", "restoreDashes",
+		"collapseWhitespace"}, names)
+}
+
+func TestWarmLanguages(t *testing.T) {
+	assert.Error(t, WarmLanguages("en", "xx"))
+	assert.NoError(t, WarmLanguages("en", "es", "fr", "tr"))
+
+	base := langToProcessor["en"]
+	sizes := func() []int {
+		r := base.abbrReplacer
+		return []int{len(r.searchCache), len(r.prepositiveCache),
+			len(r.numberCache), len(r.periodCache)}
+	}
+	warmed := sizes()
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	tok.Tokenize("I live on 5th st. near the park. See p. 5 and art. 2 etc. now.")
+
+	// Nothing new is compiled, either for the segmenter or for the text.
+	assert.Equal(t, warmed, sizes())
+	assert.Same(t, base.lookahead, tok.processor.(*processor).lookahead)
+}
+
+func TestRuleSubMultibyte(t *testing.T) {
+	r := Rule{Pattern: regexp.MustCompile(`é(\.)\s`), Replacement: "∯"}
+	assert.Equal(t, "café∯ Naïve. Résumé∯ ", r.Sub("café. Naïve. Résumé. "))
+
+	r = Rule{Pattern: regexp.MustCompile(`(∯)(é)`), Replacement: "."}
+	assert.Equal(t, "a..", r.Sub("a∯é"))
+}
+
+func TestRuleMatches(t *testing.T) {
+	r := Rule{Pattern: regexp.MustCompile(`(\w+)(\.)`), Replacement: "∯"}
+	assert.Equal(t, [][]int{
+		{0, 3, 0, 2, 2, 3},
+		{4, 7, 4, 6, 6, 7},
+	}, r.Matches("Mr. Dr. Smith"))
+	assert.Len(t, r.Matches("no periods here"), 0)
+}
+
+func TestPragmaticValidUTF8(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	for _, text := range []string{
+		"café. Next.",
+		"Il était là. Ça va? Über alles!",
+		"naïve… résumé. «Déjà vu.» Ångström.",
+		"Er sagte „Nein.“ Dann ging er. Straße.",
+		"“Olé.” é. É. ü.Ü.",
+	} {
+		for _, sent := range tok.Tokenize(text) {
+			assert.True(t, utf8.ValidString(sent), sent)
+		}
+	}
+}
+
+func TestPragmaticTerminators(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{"a|b|c"}, tok.Tokenize("a|b|c"))
+
+	tok, err = NewPragmaticSegmenter("en", WithTerminators([]rune{'|'}))
+	util.CheckError(err)
+	assert.Equal(t, []string{"a|", "b|", "c"}, tok.Tokenize("a|b|c"))
+	assert.Equal(t, []string{"Hello|", "She said (a|b) twice."},
+		tok.Tokenize("Hello| She said (a|b) twice."))
+}
+
+func TestPragmaticEmDashBoundaries(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{"I agreed—he didn't."},
+		tok.Tokenize("I agreed—he didn't."))
+
+	tok, err = NewPragmaticSegmenter("en", WithEmDashBoundaries(true))
+	util.CheckError(err)
+	for text, expected := range map[string][]string{
+		"I agreed—he didn't.":   {"I agreed—", "he didn't."},
+		"I agreed — he didn't.": {"I agreed —", "he didn't."},
+		"We had to re-enter the data. It was slow.": {
+			"We had to re-enter the data.", "It was slow."},
+		"She left—quickly—and never returned.": {
+			"She left—", "quickly—", "and never returned."},
+	} {
+		assert.Equal(t, expected, tok.Tokenize(text))
+	}
+}
+
+func TestPragmaticBlankLineBoundaries(t *testing.T) {
+	text := "the first line has no terminator\n\nthe second line neither"
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"the first line has no terminator the second line neither"},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithBlankLineBoundaries(true))
+	util.CheckError(err)
+	for text, expected := range map[string][]string{
+		text: {
+			"the first line has no terminator", "the second line neither"},
+		"one \n \n\n  two\nthree. Four.":   {"one", "two three.", "Four."},
+		"a single\nline break":             {"a single line break"},
+		"123 Main St.\nApt 4\nBoston, MA.": {"123 Main St.", "Apt 4", "Boston, MA."},
+		"Notes:\n\ni.e. it works. Then.":   {"Notes:", "i.e. it works.", "Then."},
+	} {
+		assert.Equal(t, expected, tok.Tokenize(text))
+	}
+}
+
+func TestPragmaticTabBoundaries(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{"a\tb\tc"}, tok.Tokenize("a\tb\tc"))
+
+	tok, err = NewPragmaticSegmenter("en", WithTabBoundaries(true))
+	util.CheckError(err)
+	for text, expected := range map[string][]string{
+		"a\tb\tc":                        {"a", "b", "c"},
+		"Mr. Smith\t\tsent it. It came.": {"Mr. Smith", "sent it.", "It came."},
+		"two  spaces\tand a tab":         {"two  spaces", "and a tab"},
+	} {
+		assert.Equal(t, expected, tok.Tokenize(text))
+		assert.Equal(t, expected, texts(tok.Sentences(text)))
+	}
+}
+
+func TestPragmaticSentenceStartValidator(t *testing.T) {
+	text := "See fig. 5 for details. then we go. 6 more came."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"See fig.", "5 for details.", "then we go.", "6 more came."},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en",
+		WithSentenceStartValidator(unicode.IsUpper))
+	util.CheckError(err)
+	assert.Equal(t, []string{text}, tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en",
+		WithSentenceStartValidator(func(next rune) bool {
+			return unicode.IsUpper(next) || unicode.IsDigit(next)
+		}))
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"See fig.", "5 for details. then we go.", "6 more came."},
+		tok.Tokenize(text))
+}
+
+func TestPragmaticWhitespaceCollapse(t *testing.T) {
+	// Runs of three or more whitespace characters are always reduced by the
+	// "singleNewLine" rule, so we only use shorter runs here.
+	text := "The a  b test.  It has\t\ttabs too."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{"The a  b test.", "It has\t\ttabs too."},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithWhitespaceCollapse(true))
+	util.CheckError(err)
+	assert.Equal(t, []string{"The a b test.", "It has tabs too."},
+		tok.Tokenize(text))
+}
+
+func TestPragmaticLineEndings(t *testing.T) {
+	text := "Payment due Jan.\nPlease remit.\n\nThe next line\nhas no period\n" +
+		"1. First item\n2. Second item\nHello there. General Kenobi."
+	expected := []string{
+		"Payment due Jan.", "Please remit.", "The next line has no period",
+		"1. First item", "2. Second item", "Hello there.", "General Kenobi."}
+
+	for _, opts := range [][]SegmenterOption{
+		{}, {WithBlankLineBoundaries(true)},
+	} {
+		tok, err := NewPragmaticSegmenter("en", opts...)
+		util.CheckError(err)
+		for _, eol := range []string{"\n", "\r\n", "\r"} {
+			input := strings.Replace(text, "\n", eol, -1)
+			assert.Equal(t, expected, tok.Tokenize(input), "%q", eol)
+			assert.Equal(t, expected, normalized(tok.Sentences(input)), "%q", eol)
+		}
+	}
+
+	tok, err := NewPragmaticSegmenter("en", WithPreservedLineEndings(true))
+	util.CheckError(err)
+	assert.Equal(t, expected, tok.Tokenize(text))
+	assert.NotEqual(t, expected, tok.Tokenize(strings.Replace(text, "\n", "\r", -1)))
+}
+
+func TestPragmaticRequireTerminator(t *testing.T) {
+	text := "The first sentence. The second one! And a fragment"
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"The first sentence.", "The second one!", "And a fragment"},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithRequireTerminator(true))
+	util.CheckError(err)
+	assert.Equal(t, []string{"The first sentence.", "The second one!"},
+		tok.Tokenize(text))
+	assert.Equal(t, []string{"He said \"Done.\""},
+		tok.Tokenize("He said \"Done.\""))
+}
+
+func TestPragmaticLookahead(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	for _, text := range []string{
+		"He left.\tShe stayed.",
+		"He left.\n\nShe stayed.",
+		"He left.\n \n\t\n\nShe stayed.",
+	} {
+		assert.Equal(t, []string{"He left.", "She stayed."}, tok.Tokenize(text))
+	}
+
+	text := "We left at 5 p.m.\t\tThen it rained."
+	assert.Equal(t, []string{"We left at 5 p.m.", "Then it rained."},
+		tok.Tokenize(text))
+
+	tok, err = NewPragmaticSegmenter("en", WithLookahead(0))
+	util.CheckError(err)
+	assert.Equal(t, []string{text}, tok.Tokenize(text))
+
+	// A window smaller than the gap only affects the abbreviation.
+	spaced := "We left at 5 p.m.     Then it rained."
+	tok, err = NewPragmaticSegmenter("en", WithLookahead(2))
+	util.CheckError(err)
+	assert.Equal(t, []string{"We left at 5 p.m. Then it rained."},
+		tok.Tokenize(spaced))
+	assert.Equal(t, []string{"He left.", "She stayed."},
+		tok.Tokenize("He left.     She stayed."))
+
+	// The window is capped at the regexp package's repeat limit.
+	tok, err = NewPragmaticSegmenter("en", WithLookahead(5000))
+	util.CheckError(err)
+	assert.Equal(t, []string{"We left at 5 p.m.", "Then it rained."},
+		tok.Tokenize(spaced))
+}
+
+func TestPragmaticCustomRules(t *testing.T) {
+	// "x" -> "." inside of an initialism, applied until nothing is left.
+	initials := Rule{Pattern: regexp.MustCompile(`[A-Z](x)[A-Z]`), Replacement: "."}
+	// Normalize "hello" at the start of a sentence.
+	greeting := Rule{Pattern: regexp.MustCompile(`^(hello)`), Replacement: "Hello"}
+
+	tok, err := NewPragmaticSegmenter("en",
+		WithPreRules(initials), WithPostRules(greeting))
+	util.CheckError(err)
+
+	sents, err := tok.Segment("UxSxA is big. hello there.")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"U.S.A is big.", "Hello there."}, sents)
+}
+
+func TestPragmaticJoinLinesAfterPreRules(t *testing.T) {
+	// The lines are joined after this shortens the text.
+	spelling := Rule{Pattern: regexp.MustCompile(`col(ou)r`), Replacement: "o"}
+
+	tok, err := NewPragmaticSegmenter("en", WithPreRules(spelling))
+	util.CheckError(err)
+	assert.Equal(t, []string{"A color.", "B color.", "C one, D two."},
+		tok.Tokenize("A colour. B colour.\nC one,\nD two."))
+}
+
+func TestPragmaticMalformedRules(t *testing.T) {
+	for _, bad := range []Rule{
+		{Replacement: "."},
+		{Pattern: regexp.MustCompile(`(x*)`), Replacement: "y"},
+		{Pattern: regexp.MustCompile(`(b)`), Replacement: "bb"},
+	} {
+		_, err := NewPragmaticSegmenter("en", WithPreRules(bad))
+		assert.Error(t, err)
+
+		_, isRuleErr := err.(*RuleError)
+		assert.True(t, isRuleErr, err.Error())
+	}
+
+	// This passes validation, but "ab" -> "abb" -> "abbb" -> ... never stops.
+	growing := Rule{Pattern: regexp.MustCompile(`a(b)`), Replacement: "bb"}
+	tok, err := NewPragmaticSegmenter("en", WithPostRules(growing))
+	util.CheckError(err)
+
+	sents, err := tok.Segment("I said ab. Then I left.")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "a(b)")
+	assert.Equal(t, []string{"I said ab.", "Then I left."}, sents)
+	assert.Equal(t, sents, tok.Tokenize("I said ab. Then I left."))
+}
+
+func TestPragmaticAbbreviations(t *testing.T) {
+	text := "The tank holds abt. twenty liters. See blk. 3 for details."
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, 4, len(tok.Tokenize(text)))
+
+	tok, err = NewPragmaticSegmenter("en", WithAbbreviations(" Abt. ", "blk"))
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"The tank holds abt. twenty liters.", "See blk. 3 for details."},
+		tok.Tokenize(text))
+	assert.Equal(t, []string{"abt", "blk"}, tok.Config().Abbreviations)
+
+	// An abbreviation may span several words.
+	multi := "See sections 5 et seq. for details. Then stop."
+	assert.Equal(t, 3, len(tok.Tokenize(multi)))
+	tok, err = NewPragmaticSegmenter("en", WithAbbreviations("Et  Seq."))
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"See sections 5 et seq. for details.", "Then stop."}, tok.Tokenize(multi))
+	assert.Equal(t, []string{"et seq"}, tok.Config().Abbreviations)
+
+	// The built-in segmenter is unaffected.
+	tok, err = NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, 4, len(tok.Tokenize(text)))
+
+	for _, abbr := range []string{"", "  ", ".", "a/b", "(x)", "..x", "a..b"} {
+		_, err := NewPragmaticSegmenter("en", WithAbbreviations("ok", abbr))
+		abbrErr, ok := err.(*AbbreviationError)
+		if assert.True(t, ok, "%q", abbr) {
+			assert.Equal(t, abbr, abbrErr.Abbreviation)
+		}
+	}
+}
+
+func removeWhitespace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// trickyTexts are deliberately malformed English inputs that are always
+// included in the segmentation corpus.
+var trickyTexts = []string{
+	"Hello.\n!!",
+	"I never meant that. ?",
+	"What?!\n? ?!",
+	"It was the best. . . .\n.",
+	"Wait… Über。.",
+}
+
+// segmentationCorpus collects, by language, the inputs of every golden test
+// along with some real-world and deliberately tricky text.
+func segmentationCorpus() map[string][]string {
+	return sampledCorpus(1)
+}
+
+// sampledCorpus is like segmentationCorpus, but it only keeps every nth of the
+// golden and real-world texts. This is used to check each of several options
+// without running the entire corpus through every one of them.
+func sampledCorpus(n int) map[string][]string {
+	texts := map[string][]string{}
+	for _, lang := range []string{"en", "fr", "es"} {
+		tests := make([]goldenRule, 0)
+		f := fmt.Sprintf("golden_rules_%s.json", lang)
+		util.CheckError(json.Unmarshal(util.ReadDataFile(filepath.Join(testdata, f)), &tests))
+		for _, test := range tests {
+			texts[lang] = append(texts[lang], test.Input)
+		}
+	}
+	article := util.ReadDataFile(filepath.Join(testdata, "article.txt"))
+	texts["en"] = append(texts["en"], strings.Split(string(article), "\n\n")...)
+
+	corpus := map[string][]string{"en": append([]string{}, trickyTexts...)}
+	for lang, all := range texts {
+		for i := 0; i < len(all); i += n {
+			corpus[lang] = append(corpus[lang], all[i])
+		}
+	}
+	return corpus
+}
+
+// checkCorpus calls check with every sentence that a segmenter returns for a
+// sample of the corpus, with suffix appended to each text: a quarter of it for
+// the default options, and a smaller sample for each of options.
+func checkCorpus(options [][]SegmenterOption, suffix string, check func(text, sent string)) {
+	for lang, texts := range sampledCorpus(4) {
+		tok, err := NewPragmaticSegmenter(lang)
+		util.CheckError(err)
+		for _, text := range texts {
+			for _, sent := range tok.Tokenize(text + suffix) {
+				check(text, sent)
+			}
+		}
+	}
+	for lang, texts := range sampledCorpus(16) {
+		for _, opts := range options {
+			tok, err := NewPragmaticSegmenter(lang, opts...)
+			util.CheckError(err)
+			for _, text := range texts {
+				for _, sent := range tok.Tokenize(text + suffix) {
+					check(text, sent)
+				}
+			}
+		}
+	}
+}
+
+func TestPragmaticPreservesContent(t *testing.T) {
+	corpus := segmentationCorpus()
+	for lang, texts := range corpus {
+		tok, err := NewPragmaticSegmenter(lang)
+		util.CheckError(err)
+		for _, text := range texts {
+			joined := strings.Join(tok.Tokenize(text), "")
+			assert.Equal(t, removeWhitespace(text), removeWhitespace(joined))
+		}
+	}
+}
+
+// sentinelRE matches the placeholders used to protect punctuation during
+// segmentation, none of which should ever appear in the output.
+var sentinelRE = regexp.MustCompile(
+	`[∯∮ƪ♟♝☏♬♭☉☇☈☄ȸȹ\x{e000}-\x{f8ff}]|&[ᓰᓱᓳᓴᓷᓸᓹᓺᓻᓼᓽᓾ⎋⎌⎍⎎✂⌬]&`)
+
+func TestPragmaticNoSentinels(t *testing.T) {
+	options := [][]SegmenterOption{
+		{WithFastPath(true)},
+		{WithTerminators([]rune{'|', ';'})},
+		{WithListItems(true), WithWhitespaceCollapse(true)},
+	}
+	checkCorpus(options, "", func(text, sent string) {
+		if !sentinelRE.MatchString(text) {
+			assert.False(t, sentinelRE.MatchString(sent), sent)
+		}
+	})
+}
+
+func TestPragmaticNoTrailingWhitespace(t *testing.T) {
+	options := [][]SegmenterOption{
+		{WithScanner(true)},
+		{WithPreservedLineEndings(true)},
+		{WithListItems(true)},
+		{WithBlankLineBoundaries(true)},
+		{WithEmDashBoundaries(true)},
+		{WithTabBoundaries(true)},
+		{WithAllowNoSpaceBoundaries(true)},
+		{WithMergeShortFragments(20)},
+		{WithTerminators([]rune{'|', ';'})},
+		{WithFastPath(true)},
+		{WithQuoteProtection(false)},
+		{WithStripOuterQuotes(true)},
+		{WithClean(true)},
+	}
+	checkCorpus(options, "\u00a0\u200b\u3000\t \n", func(text, sent string) {
+		assert.Equal(t, strings.TrimRightFunc(sent, isSpace), sent)
+	})
+}
+
+func TestPragmaticInvisibleSpace(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := "Hello.\u200b World.\u2060 Hi.\ufeff\nThere."
+	assert.Equal(t, []string{"Hello.", "World.", "Hi.", "There."}, tok.Tokenize(text))
+	for _, sent := range tok.Sentences(text) {
+		assert.Equal(t, sent.Text, text[sent.Start:sent.End])
+	}
+}
+
+func TestPragmaticTrailingNewline(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	for _, text := range []string{
+		"Hello world. Bye. . . .",
+		"He left.\nThe practice was not abandoned. . . .",
+		"I am here!!! Are you?",
+		"It ended abruptly",
+	} {
+		expected := tok.Tokenize(text)
+		for _, suffix := range []string{"\n", "\r\n", "\n\n", " \n", " "} {
+			assert.Equal(t, expected, tok.Tokenize(text+suffix), "%q", suffix)
+		}
+	}
+}
+
+func TestPragmaticQuotePairs(t *testing.T) {
+	german := "Er sagte „Halt. Jetzt!“ Dann ging er."
+	japanese := "彼は「止まれ。今だ！」と言った。"
+
+	// Neither pair is protected by default.
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, "Er sagte „Halt.", tok.Tokenize(german)[0])
+	assert.Equal(t, "彼は「止まれ。", tok.Tokenize(japanese)[0])
+
+	tok, err = NewPragmaticSegmenter("en",
+		WithQuotePairs([]QuotePair{{'„', '“'}, {'「', '」'}}))
+	util.CheckError(err)
+	assert.Equal(t, []string{"Er sagte „Halt. Jetzt!“", "Dann ging er."},
+		tok.Tokenize(german))
+	assert.Equal(t, []string{japanese}, tok.Tokenize(japanese))
+	assert.Equal(t, [][2]int{{9, 27}}, tok.Masked(german).Ranges)
+
+	// The language's own pairs are replaced, not extended.
+	assert.Equal(t, "He said “Stop.", tok.Tokenize("He said “Stop. Now!”")[0])
+
+	loaded, err := NewFromConfig(tok.Config())
+	util.CheckError(err)
+	assert.Equal(t, "„“「」", *loaded.Config().QuotePairs)
+	assert.Equal(t, tok.Tokenize(german), loaded.Tokenize(german))
+}
+
+func TestPragmaticLanguageDefaults(t *testing.T) {
+	text := "Er sagte „Halt. Jetzt!“ Dann kam Dr. Müller, bzw. Frau Nr. 5 kam."
+
+	tok, err := NewPragmaticSegmenter("de")
+	util.CheckError(err)
+	assert.Equal(t, []string{
+		"Er sagte „Halt. Jetzt!“", "Dann kam Dr. Müller, bzw. Frau Nr. 5 kam."},
+		tok.Tokenize(text))
+	assert.Nil(t, tok.Config().QuotePairs)
+
+	// Each default can still be overridden.
+	tok, err = NewPragmaticSegmenter("de", WithQuotePairs(nil))
+	util.CheckError(err)
+	assert.Equal(t, "Er sagte „Halt.", tok.Tokenize(text)[0])
+	assert.Equal(t, "", *tok.Config().QuotePairs)
+}
+
+func TestPragmaticLiteralSentinels(t *testing.T) {
+	texts := []string{
+		`He typed "&ᓷ&" and "∯" here. Then ȸ left.`,
+		"Weird &⎋& text. Also ☉ and ♬ signs (see &✂&). Done.",
+		"A private \ue000 rune | and ƪ here. Next.",
+	}
+	for _, opts := range [][]SegmenterOption{
+		{}, {WithTerminators([]rune{'|'})}, {WithFastPath(true)},
+	} {
+		tok, err := NewPragmaticSegmenter("en", opts...)
+		util.CheckError(err)
+		for _, text := range texts {
+			joined := strings.Join(tok.Tokenize(text), "")
+			assert.Equal(t, removeWhitespace(text), removeWhitespace(joined))
+		}
+	}
+
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	assert.Equal(t, []string{`He typed "&ᓷ&" and "∯" here.`, "Then ȸ left."},
+		tok.Tokenize(texts[0]))
+}
@@ -0,0 +1,87 @@
+package tokenize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Span is a passage of text along with its position in the text that it
+// was found in.
+type Span struct {
+	// Text is the passage exactly as it appears in the original input,
+	// text[Start:End].
+	Text string
+	// Start and End are the byte offsets of Text in the original input.
+	Start, End int
+}
+
+// quotedSpans lists the regexps used by ExtractQuotes: the straight quotes
+// masked by replaceBetweenQuotes, followed by the smart quotes and guillemets
+// masked by each of the supported languages.
+var quotedSpans = append([]*regexp.Regexp{
+	betweenDoubleQuotesRE,
+	betweenSingleQuotesRE,
+	// Like betweenSingleQuotesRE, this allows for apostrophes (e.g.,
+	// "‘I can’t.’").
+	regexp.MustCompile(`(?:^|\s)‘(?:[^’\p{L}]|\p{L}+(?:’\p{L}+)*)*’`),
+}, newQuoteRegexps([]QuotePair{
+	{'“', '”'}, {'«', '»'}, {'‹', '›'}, {'„', '“'}})...)
+
+// ExtractQuotes returns the passages of text that are enclosed by straight
+// quotes, smart quotes, or guillemets, in the order that they appear. Each
+// Span holds the passage without its quotation marks.
+//
+// A quotation nested inside of another is returned after it, but quotes whose
+// marks cross each other (which is usually the result of a missing mark) are
+// only returned once.
+func ExtractQuotes(text string) []Span {
+	matches := []quoteMatch{}
+	for _, re := range quotedSpans {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			// The single-quote regexps include any preceding whitespace.
+			quoted := strings.TrimLeftFunc(text[loc[0]:loc[1]], unicode.IsSpace)
+			matches = append(matches, quoteMatch{loc[1] - len(quoted), loc[1]})
+		}
+	}
+	sort.Stable(byQuoteStart(matches))
+
+	spans := []Span{}
+	outer := []quoteMatch{}
+	for _, m := range matches {
+		for len(outer) > 0 && outer[len(outer)-1].end <= m.start {
+			outer = outer[:len(outer)-1]
+		}
+		if len(outer) > 0 && m.end > outer[len(outer)-1].end {
+			continue
+		}
+		outer = append(outer, m)
+
+		_, openSize := utf8.DecodeRuneInString(text[m.start:])
+		_, closeSize := utf8.DecodeLastRuneInString(text[:m.end])
+		start, end := m.start+openSize, m.end-closeSize
+		if start < end {
+			spans = append(spans, Span{Text: text[start:end], Start: start, End: end})
+		}
+	}
+	return spans
+}
+
+// A quoteMatch is the [start, end) byte offsets of a quotation, including its
+// quotation marks.
+type quoteMatch struct{ start, end int }
+
+// byQuoteStart orders quotations by their start, placing the longer of two
+// quotations with the same start (i.e., the outer one) first.
+type byQuoteStart []quoteMatch
+
+func (s byQuoteStart) Len() int      { return len(s) }
+func (s byQuoteStart) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s byQuoteStart) Less(i, j int) bool {
+	if s[i].start != s[j].start {
+		return s[i].start < s[j].start
+	}
+	return s[i].end > s[j].end
+}
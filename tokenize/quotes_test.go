@@ -0,0 +1,35 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractQuotes(t *testing.T) {
+	text := `"I'm leaving," she said. “And I won't be back.”`
+	spans := ExtractQuotes(text)
+	assert.Equal(t, []Span{
+		{Text: "I'm leaving,", Start: 1, End: 13},
+		{Text: "And I won't be back.", Start: 28, End: 48},
+	}, spans)
+	for _, span := range spans {
+		assert.Equal(t, span.Text, text[span.Start:span.End])
+	}
+
+	assert.Equal(t, []string{"Bonjour", "salut"},
+		spanTexts(ExtractQuotes("Il a dit «Bonjour» et ‹salut›.")))
+	assert.Equal(t, []string{"He said ‘I can’t.’ and left.", "I can’t."},
+		spanTexts(ExtractQuotes("“He said ‘I can’t.’ and left.”")))
+	assert.Equal(t, []string{"unterminated"},
+		spanTexts(ExtractQuotes(`"unterminated" and "open`)))
+	assert.Empty(t, ExtractQuotes("No quotes here, just Bob's dog."))
+}
+
+func spanTexts(spans []Span) []string {
+	texts := []string{}
+	for _, span := range spans {
+		texts = append(texts, span.Text)
+	}
+	return texts
+}
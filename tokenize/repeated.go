@@ -0,0 +1,17 @@
+package tokenize
+
+import "regexp"
+
+var repeatedExclamationRE = regexp.MustCompile(`!{2,}`)
+var repeatedQuestionRE = regexp.MustCompile(`\?{2,}`)
+
+// collapseRepeated replaces each run of exclamation or question marks in sent
+// with a single mark if the segmenter was created using
+// WithPreserveRepeatedTerminators(false). Otherwise, sent is returned as is.
+func (p *PragmaticSegmenter) collapseRepeated(sent string) string {
+	if !p.opts.collapseRepeated {
+		return sent
+	}
+	sent = repeatedExclamationRE.ReplaceAllString(sent, "!")
+	return repeatedQuestionRE.ReplaceAllString(sent, "?")
+}
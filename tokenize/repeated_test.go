@@ -0,0 +1,52 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreserveRepeatedTerminators(t *testing.T) {
+	tests := []struct {
+		text      string
+		kept      []string
+		collapsed []string
+	}{
+		// A run of three or more ends the sentence before a capital letter.
+		{"Wow!!! Great.",
+			[]string{"Wow!!!", "Great."},
+			[]string{"Wow!", "Great."}},
+		{"Wow!! Great.",
+			[]string{"Wow!!", "Great."},
+			[]string{"Wow!", "Great."}},
+		{"Really?? Yes!!",
+			[]string{"Really??", "Yes!!"},
+			[]string{"Really?", "Yes!"}},
+		// Alternating marks aren't a run.
+		{"What?! No.",
+			[]string{"What?!", "No."},
+			[]string{"What?!", "No."}},
+	}
+	for _, test := range tests {
+		tok, err := NewPragmaticSegmenter("en")
+		util.CheckError(err)
+		assert.Equal(t, test.kept, tok.Tokenize(test.text), test.text)
+
+		tok, err = NewPragmaticSegmenter("en", WithPreserveRepeatedTerminators(false))
+		util.CheckError(err)
+		assert.Equal(t, test.collapsed, tok.Tokenize(test.text), test.text)
+
+		// The offsets still refer to the original text.
+		for i, sent := range tok.Sentences(test.text) {
+			assert.Equal(t, test.kept[i], test.text[sent.Start:sent.End])
+			assert.Equal(t, test.collapsed[i], sent.Normalized)
+		}
+	}
+
+	tok, err := NewPragmaticSegmenter("en", WithPreserveRepeatedTerminators(false))
+	util.CheckError(err)
+	loaded, err := NewFromConfig(tok.Config())
+	util.CheckError(err)
+	assert.Equal(t, []string{"Wow!", "Great."}, loaded.Tokenize("Wow!! Great."))
+}
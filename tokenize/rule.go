@@ -0,0 +1,59 @@
+package tokenize
+
+import (
+	"regexp"
+	"sync"
+)
+
+// maxCachedPatterns is the number of patterns that ruleCache holds before it
+// starts to evict the oldest of them.
+const maxCachedPatterns = 128
+
+// ruleCache holds the patterns most recently compiled by CompileRule, keyed by
+// their source text; order lists the same keys from oldest to newest.
+var ruleCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+	order    []string
+}{patterns: make(map[string]*regexp.Regexp)}
+
+// CompileRule creates a Rule from the given pattern and replacement.
+//
+// Unlike building a Rule from regexp.MustCompile, an invalid pattern is
+// returned as an error rather than a panic, and the Rule is validated in the
+// same way as those registered by WithPreRules (see RuleError). Recently
+// compiled patterns are cached, so rules built from the same pattern usually
+// share a single *regexp.Regexp.
+func CompileRule(pattern, replacement string) (Rule, error) {
+	re, err := compilePattern(pattern)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule := Rule{Pattern: re, Replacement: replacement}
+	if err = rule.validate(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// compilePattern returns the cached *regexp.Regexp for pattern, compiling it
+// if necessary.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	ruleCache.Lock()
+	defer ruleCache.Unlock()
+
+	if re, ok := ruleCache.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(ruleCache.order) == maxCachedPatterns {
+		delete(ruleCache.patterns, ruleCache.order[0])
+		ruleCache.order = ruleCache.order[1:]
+	}
+	ruleCache.patterns[pattern] = re
+	ruleCache.order = append(ruleCache.order, pattern)
+	return re, nil
+}
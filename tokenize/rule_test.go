@@ -0,0 +1,42 @@
+package tokenize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileRule(t *testing.T) {
+	first, err := CompileRule(`\b(J\.)\s`, "Jay")
+	util.CheckError(err)
+	second, err := CompileRule(`\b(J\.)\s`, "J")
+	util.CheckError(err)
+
+	assert.True(t, first.Pattern == second.Pattern)
+	assert.Equal(t, "Jay", first.Replacement)
+	assert.Equal(t, "J", second.Replacement)
+	assert.Equal(t, "Jay left.", first.Sub("J. left."))
+
+	tok, err := NewPragmaticSegmenter("en", WithPreRules(first))
+	util.CheckError(err)
+	assert.Equal(t, []string{"Jay left.", "She stayed."},
+		tok.Tokenize("J. left. She stayed."))
+
+	_, err = CompileRule(`(`, "")
+	assert.Error(t, err)
+
+	_, err = CompileRule(`(x*)`, "y")
+	if assert.Error(t, err) {
+		assert.IsType(t, &RuleError{}, err)
+	}
+
+	for i := 0; i <= maxCachedPatterns; i++ {
+		_, err = CompileRule(fmt.Sprintf(`(x%d)`, i), "y")
+		util.CheckError(err)
+	}
+	assert.Equal(t, maxCachedPatterns, len(ruleCache.patterns))
+	_, cached := ruleCache.patterns[`(x0)`]
+	assert.False(t, cached)
+}
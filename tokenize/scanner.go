@@ -0,0 +1,119 @@
+package tokenize
+
+import (
+	"regexp"
+	"strings"
+)
+
+// scanner is a hand-written alternative to the regexp-based processor for
+// English.
+//
+// It makes a single pass over the input and handles "plain" text on its own:
+// ASCII prose in which every terminator ends a word and is followed by a
+// single space and a capital letter (or the end of the text). Anything else
+// (quotes, parentheses, abbreviations, numbers, newlines, etc.) is handed off
+// to the full processor, so the output is always the same as it would be
+// without WithScanner.
+type scanner struct {
+	fallback      *processor
+	abbreviations map[string]bool
+}
+
+func newScanner(fallback *processor) *scanner {
+	abbrs := map[string]bool{}
+	for _, abbr := range fallback.abbrReplacer.definition.abbreviations()["abbreviations"] {
+		abbrs[strings.ToLower(strings.TrimSpace(abbr))] = true
+	}
+	return &scanner{fallback: fallback, abbreviations: abbrs}
+}
+
+func (s *scanner) configure(opts *segmenterOptions) languageProcessor {
+	return s.fallback.configure(opts)
+}
+
+func (s *scanner) language() languageDefinition {
+	return s.fallback.language()
+}
+
+func (s *scanner) quoteRegexps() []*regexp.Regexp {
+	return s.fallback.quoteRegexps()
+}
+
+func (s *scanner) process(text string) ([]string, error) {
+	if sents, ok := s.scan(text); ok {
+		return sents, nil
+	}
+	return s.fallback.process(text)
+}
+
+func (s *scanner) locate(text string, sents []string) [][2]int {
+	return s.fallback.locate(text, sents)
+}
+
+// scan splits text into sentences, reporting false if text isn't plain
+// enough for the result to be trusted.
+func (s *scanner) scan(text string) ([]string, bool) {
+	text = strings.Trim(text, " ")
+	if text == "" {
+		return nil, false
+	}
+
+	sents := []string{}
+	start, wordStart := 0, 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case isASCIILetter(c) || isASCIIDigit(c):
+		case c == ',' || c == ';' || c == ':':
+		case c == '-':
+			if i == 0 || !isASCIILetter(text[i-1]) {
+				return nil, false
+			}
+		case c == ' ':
+			if text[i-1] == ' ' {
+				return nil, false
+			}
+			wordStart = i + 1
+		case c == '.' || c == '!' || c == '?':
+			word := text[wordStart:i]
+			if len(word) < 2 || !isASCIILetter(word[len(word)-1]) {
+				// Initials, numbers, and repeated punctuation.
+				return nil, false
+			}
+			if c == '.' && s.abbreviations[strings.ToLower(word)] {
+				return nil, false
+			}
+			if c == '!' && exclamationWordsRE.MatchString(" "+word+"! ") {
+				return nil, false
+			}
+			if i+1 < len(text) {
+				if i+2 >= len(text) || text[i+1] != ' ' || !isASCIIUpper(text[i+2]) {
+					return nil, false
+				}
+			}
+			sents = append(sents, text[start:i+1])
+			start = i + 2
+		default:
+			// Quotes, brackets, symbols, control characters, and non-ASCII
+			// text are all left to the full processor.
+			return nil, false
+		}
+	}
+
+	if start < len(text) {
+		sents = append(sents, text[start:])
+	}
+	return sents, true
+}
+
+func isASCIILetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || isASCIIUpper(c)
+}
+
+func isASCIIUpper(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
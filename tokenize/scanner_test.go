@@ -0,0 +1,81 @@
+package tokenize
+
+import (
+	"encoding/json"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+var plainText = strings.Repeat("The quick brown fox jumps over the lazy dog. "+
+	"It was not the first time, and it would not be the last. "+
+	"Why does the fox keep jumping? Nobody knows for sure! "+
+	"Some say it likes the exercise; others say it gets bored. ", 20)
+
+func BenchmarkPragmaticRules(b *testing.B) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+	for n := 0; n < b.N; n++ {
+		tok.Tokenize(plainText)
+	}
+}
+
+func BenchmarkPragmaticScanner(b *testing.B) {
+	tok, err := NewPragmaticSegmenter("en", WithScanner(true))
+	util.CheckError(err)
+	for n := 0; n < b.N; n++ {
+		tok.Tokenize(plainText)
+	}
+}
+
+func TestScannerGolden(t *testing.T) {
+	tests := make([]goldenRule, 0)
+	cases := util.ReadDataFile(filepath.Join(testdata, "golden_rules_en.json"))
+	util.CheckError(json.Unmarshal(cases, &tests))
+
+	tok, err := NewPragmaticSegmenter("en", WithScanner(true))
+	util.CheckError(err)
+	for _, test := range tests {
+		compare(t, test.Name, test.Input, test.Output, tok)
+	}
+}
+
+func TestScannerMatchesRules(t *testing.T) {
+	rules, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	scanner := newScanner(newProcessor("en"))
+	words := []string{
+		"the", "fox", "It", "I", "is", "Mr", "dr", "a", "US", "jumps", "Yahoo",
+		"x2", "3", "well-known", "end,", "yes;", "Note:", "said", "Smith",
+	}
+	terminators := []string{"", "", "", ".", ".", "!", "?"}
+
+	rng := rand.New(rand.NewSource(1))
+	scanned := 0
+	for n := 0; n < 5000; n++ {
+		parts := []string{}
+		for i := 0; i < 2+rng.Intn(12); i++ {
+			parts = append(parts,
+				words[rng.Intn(len(words))]+terminators[rng.Intn(len(terminators))])
+		}
+		text := strings.Join(parts, " ")
+		if rng.Intn(4) == 0 {
+			text = strings.Repeat(" ", rng.Intn(3)) + text + strings.Repeat(" ", rng.Intn(3))
+		}
+
+		if sents, ok := scanner.scan(text); ok {
+			assert.Equal(t, rules.Tokenize(text), sents, text)
+			scanned++
+		}
+	}
+	assert.True(t, scanned > 500)
+
+	tok, err := NewPragmaticSegmenter("en", WithScanner(true))
+	util.CheckError(err)
+	assert.Equal(t, rules.Tokenize(plainText), tok.Tokenize(plainText))
+}
@@ -0,0 +1,142 @@
+package tokenize
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A Sentence is a single segment of text produced by a PragmaticSegmenter.
+type Sentence struct {
+	// Text is the sentence exactly as it appears in the original input,
+	// text[Start:End].
+	Text string
+	// Normalized is the sentence as returned by Tokenize: that is, with the
+	// segmenter's normalization options (e.g., WithWhitespaceCollapse and
+	// WithNormalizedDashOutput) and any post-rules applied.
+	Normalized string
+	// Runes is the number of runes (rather than bytes) in Text, which is a
+	// better estimate of its displayed width.
+	Runes int
+	// Start and End are the byte offsets of Text in the original input.
+	Start, End int
+	// Line is the 1-based line of the original input on which Text starts.
+	Line int
+	// Trailing is the run of whitespace (e.g., " ", "  ", or "\n\n") that
+	// followed Text in the original input.
+	Trailing string
+	// Forced is true if Text is part of a sentence that had to be broken up
+	// to satisfy a length limit, rather than a complete sentence.
+	Forced bool
+	// Confidence is a rough measure, in [0, 1], of how certain the boundary
+	// at the end of Text is (see Sentences).
+	Confidence float64
+}
+
+// The confidence assigned to each kind of boundary, from most to least
+// certain.
+const (
+	endOfTextConfidence   = 1.0
+	capitalizedConfidence = 0.9
+	lineBreakConfidence   = 0.7
+	terminatorConfidence  = 0.6
+	inferredConfidence    = 0.3
+	forcedConfidence      = 0.1
+)
+
+// Sentences is like Tokenize, but it returns each sentence along with its
+// location in text and the confidence of the boundary that ends it:
+//
+//	1.0  the end of the text
+//	0.9  a terminator followed by a capitalized word ("X. Y.")
+//	0.7  a line break
+//	0.6  a terminator followed by anything else ("X. y.")
+//	0.3  anything else (e.g., a lone terminator attached to a segment)
+//
+// Pieces of a sentence produced by TokenizeMaxTokens are given 0.1.
+func (p *PragmaticSegmenter) Sentences(text string) []Sentence {
+	detailed := p.sentences(text)
+	for i := range detailed {
+		detailed[i].Normalized = p.output(detailed[i].Normalized)
+	}
+	return detailed
+}
+
+// sentences is like Sentences, but it doesn't apply the output-only options
+// (see output) to Normalized.
+func (p *PragmaticSegmenter) sentences(text string) []Sentence {
+	sents, located, _ := p.segment(text)
+	offsets := p.processor.locate(text, located)
+
+	detailed := make([]Sentence, len(sents))
+	line, counted := 1, 0
+	for i, sent := range sents {
+		confidence := endOfTextConfidence
+		if i < len(sents)-1 {
+			gap := text[offsets[i][1]:offsets[i+1][0]]
+			confidence = boundaryConfidence(sent, sents[i+1], gap)
+		}
+		raw := text[offsets[i][0]:offsets[i][1]]
+		line += strings.Count(text[counted:offsets[i][0]], "\n")
+		counted = offsets[i][0]
+		detailed[i] = Sentence{
+			Text:       raw,
+			Normalized: sent,
+			Runes:      utf8.RuneCountInString(raw),
+			Start:      offsets[i][0],
+			End:        offsets[i][1],
+			Line:       line,
+			Trailing:   leadingSpace(text[offsets[i][1]:]),
+			Confidence: confidence,
+		}
+	}
+
+	return detailed
+}
+
+// SentenceAt returns the sentence in text that contains the given byte offset
+// (e.g., a cursor position), as located by Sentences. It returns false if the
+// offset falls between sentences or outside of text.
+func (p *PragmaticSegmenter) SentenceAt(text string, offset int) (Sentence, bool) {
+	sents := p.Sentences(text)
+	i := sort.Search(len(sents), func(i int) bool {
+		return sents[i].End > offset
+	})
+	if i < len(sents) && sents[i].Start <= offset {
+		return sents[i], true
+	}
+	return Sentence{}, false
+}
+
+// leadingSpace returns the run of whitespace at the start of text.
+func leadingSpace(text string) string {
+	if idx := strings.IndexFunc(text, func(r rune) bool {
+		return !isSpace(r)
+	}); idx >= 0 {
+		return text[:idx]
+	}
+	return text
+}
+
+// terminalPunctuation lists the characters that end a sentence by default.
+const terminalPunctuation = "。．.！!?？‽‼⁇⁈⁉⸮"
+
+// boundaryConfidence scores the boundary between sent and next, which are
+// separated by gap in the original text.
+func boundaryConfidence(sent, next, gap string) float64 {
+	last, _ := utf8.DecodeLastRuneInString(
+		strings.TrimRight(sent, `"'”’»)]}`))
+	first, _ := utf8.DecodeRuneInString(
+		strings.TrimLeft(next, `"'“‘«([{¿¡`))
+
+	switch {
+	case strings.ContainsRune(terminalPunctuation, last) && unicode.IsUpper(first):
+		return capitalizedConfidence
+	case strings.Contains(gap, "\n"):
+		return lineBreakConfidence
+	case strings.ContainsRune(terminalPunctuation, last):
+		return terminatorConfidence
+	}
+	return inferredConfidence
+}
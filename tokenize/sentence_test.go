@@ -0,0 +1,208 @@
+package tokenize
+
+import (
+	"regexp"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentencesConfidence(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	sents := tok.Sentences("I went home. It was late.\nThe end")
+	assert.Equal(t, []Sentence{
+		{Text: "I went home.", Normalized: "I went home.", Runes: 12,
+			Start: 0, End: 12, Line: 1, Trailing: " ", Confidence: 0.9},
+		{Text: "It was late.", Normalized: "It was late.", Runes: 12,
+			Start: 13, End: 25, Line: 1, Trailing: "\n", Confidence: 0.9},
+		{Text: "The end", Normalized: "The end", Runes: 7,
+			Start: 26, End: 33, Line: 2, Confidence: 1.0},
+	}, sents)
+
+	sents = tok.Sentences("First line\nSecond line")
+	assert.Equal(t, 0.7, sents[0].Confidence)
+
+	// A clear "X. Y." boundary outranks a soft, length-based split.
+	clear := tok.Sentences("X is here. Y is there.")[0]
+	soft := tok.TokenizeMaxTokens("X is here, and Y is there, and Z is everywhere.", 4)[0]
+	assert.True(t, soft.Forced)
+	assert.True(t, clear.Confidence > soft.Confidence)
+}
+
+func TestSentencesNormalized(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en",
+		WithWhitespaceCollapse(true),
+		WithDashNormalization('-'), WithNormalizedDashOutput(true))
+	util.CheckError(err)
+
+	text := "He said, “It’s  a  well–known\nfact.”  Then   he left."
+	sents := tok.Sentences(text)
+	assert.Equal(t, []string{
+		"He said, “It’s  a  well–known\nfact.”", "Then   he left."},
+		texts(sents))
+	assert.Equal(t, []string{
+		"He said, “It’s a well-known fact.”", "Then he left."},
+		normalized(sents))
+	assert.Equal(t, tok.Tokenize(text), normalized(sents))
+	for _, sent := range sents {
+		assert.Equal(t, text[sent.Start:sent.End], sent.Text)
+	}
+
+	pieces := tok.TokenizeMaxTokens(text, 4)
+	assert.Equal(t, []string{
+		"He said,", "“It’s  a  well–known\nfact.”", "Then   he left."},
+		texts(pieces))
+	assert.Equal(t, []string{
+		"He said,", "“It’s a well-known fact.”", "Then he left."},
+		normalized(pieces))
+}
+
+func TestSentencesCustomRules(t *testing.T) {
+	// Each of these changes the length of the text that it's applied to.
+	spelling := Rule{Pattern: regexp.MustCompile(`col(ou)r`), Replacement: "o"}
+	ampersand := Rule{Pattern: regexp.MustCompile(`\s(&)\s`), Replacement: "and"}
+	greeting := Rule{Pattern: regexp.MustCompile(`^(Hi)\b`), Replacement: "Hello"}
+
+	tok, err := NewPragmaticSegmenter("en",
+		WithPreRules(spelling, ampersand), WithPostRules(greeting))
+	util.CheckError(err)
+
+	text := "The colour\nof the sky,\nis blue. Hi there. Salt & pepper."
+	sents := tok.Sentences(text)
+	assert.Equal(t, []string{
+		"The colour\nof the sky,\nis blue.", "Hi there.", "Salt & pepper."},
+		texts(sents))
+	assert.Equal(t, []string{
+		"The color of the sky, is blue.", "Hello there.", "Salt and pepper."},
+		normalized(sents))
+	assert.Equal(t, tok.Tokenize(text), normalized(sents))
+	for _, sent := range sents {
+		assert.Equal(t, text[sent.Start:sent.End], sent.Text)
+	}
+}
+
+func texts(sents []Sentence) []string {
+	strs := []string{}
+	for _, sent := range sents {
+		strs = append(strs, sent.Text)
+	}
+	return strs
+}
+
+func normalized(sents []Sentence) []string {
+	strs := []string{}
+	for _, sent := range sents {
+		strs = append(strs, sent.Normalized)
+	}
+	return strs
+}
+
+func TestSentencesRunes(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	sents := tok.Sentences("Café au lait. Ça va? 東京は大きい。 Done.")
+	assert.Equal(t, []int{13, 6, 7, 5}, runes(sents))
+	for _, sent := range sents[:3] {
+		assert.True(t, sent.Runes < len(sent.Text), sent.Text)
+	}
+	assert.Equal(t, len(sents[3].Text), sents[3].Runes)
+
+	sents = tok.TokenizeMaxTokens("Señor Núñez walked, and then he ran.", 3)
+	assert.True(t, len(sents) > 1)
+	for _, sent := range sents {
+		assert.Equal(t, utf8.RuneCountInString(sent.Text), sent.Runes)
+	}
+}
+
+func runes(sents []Sentence) []int {
+	counts := []int{}
+	for _, sent := range sents {
+		counts = append(counts, sent.Runes)
+	}
+	return counts
+}
+
+func TestSentencesTrailing(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := "  One.  Two.\nThree.\t\n\nFour five six seven. Eight. \n"
+	sents := tok.Sentences(text)
+	assert.Equal(t, []string{"  ", "\n", "\t\n\n", " ", " \n"},
+		trailing(sents))
+
+	rebuilt := text[:sents[0].Start]
+	for i, sent := range sents {
+		assert.Equal(t, sent.Text, text[sent.Start:sent.End])
+		if i < len(sents)-1 {
+			assert.Equal(t, text[sent.End:sents[i+1].Start], sent.Trailing)
+		}
+		rebuilt += text[sent.Start:sent.End] + sent.Trailing
+	}
+	assert.Equal(t, text, rebuilt)
+
+	// Pieces of a split sentence also record the whitespace between them.
+	pieces := tok.TokenizeMaxTokens(text, 3)
+	assert.Equal(t, []string{"  ", "\n", "\t\n\n", " ", " ", " \n"},
+		trailing(pieces))
+}
+
+func TestSentencesLine(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := "\n# Notes\n\nThe first point. The second\npoint spans two lines.\n\n" +
+		"Last one.\r\nReally."
+	sents := tok.Sentences(text)
+	assert.Equal(t, []string{"# Notes", "The first point.",
+		"The second\npoint spans two lines.", "Last one.", "Really."}, texts(sents))
+	assert.Equal(t, []int{2, 4, 4, 7, 8}, lines(sents))
+
+	// Pieces of a split sentence record their own lines.
+	pieces := tok.TokenizeMaxTokens(text, 3)
+	assert.Equal(t, "The second\npoint", pieces[3].Text)
+	assert.Equal(t, []int{2, 4, 4, 4, 5, 5, 7, 8}, lines(pieces))
+}
+
+func TestSentenceAt(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := "  One.  Two is here.\nThree."
+	for offset, expected := range map[int]string{
+		2: "One.", 5: "One.", 8: "Two is here.", 19: "Two is here.",
+		21: "Three.", 26: "Three.",
+	} {
+		sent, ok := tok.SentenceAt(text, offset)
+		assert.True(t, ok, "%d", offset)
+		assert.Equal(t, expected, sent.Text, "%d", offset)
+	}
+
+	// Offsets in the whitespace between sentences, or outside of the text,
+	// aren't part of any sentence.
+	for _, offset := range []int{-1, 0, 1, 6, 7, 20, 27, 100} {
+		_, ok := tok.SentenceAt(text, offset)
+		assert.False(t, ok, "%d", offset)
+	}
+}
+
+func lines(sents []Sentence) []int {
+	numbers := []int{}
+	for _, sent := range sents {
+		numbers = append(numbers, sent.Line)
+	}
+	return numbers
+}
+
+func trailing(sents []Sentence) []string {
+	gaps := []string{}
+	for _, sent := range sents {
+		gaps = append(gaps, sent.Trailing)
+	}
+	return gaps
+}
@@ -0,0 +1,70 @@
+package tokenize
+
+import (
+	"sort"
+	"strings"
+)
+
+// SegmentStats summarizes the sentences of a document, as produced by
+// PragmaticSegmenter.Stats.
+type SegmentStats struct {
+	// Sentences is the number of sentences in the document.
+	Sentences int
+	// The mean, median, and maximum length of a sentence in words (i.e.,
+	// runs of non-whitespace characters).
+	MeanWords, MedianWords float64
+	MaxWords               int
+	// The mean, median, and maximum length of a sentence in runes.
+	MeanRunes, MedianRunes float64
+	MaxRunes               int
+	// ClearBoundaries counts the sentences that end with a clear boundary (a
+	// terminator followed by a capitalized word or the end of the text);
+	// InferredBoundaries counts the rest (see Sentences).
+	ClearBoundaries, InferredBoundaries int
+}
+
+// Stats segments text and summarizes the resulting sentences, which is
+// useful for checking the quality of a corpus.
+func (p *PragmaticSegmenter) Stats(text string) SegmentStats {
+	sents := p.Sentences(text)
+	stats := SegmentStats{Sentences: len(sents)}
+	if len(sents) == 0 {
+		return stats
+	}
+
+	words := make([]int, len(sents))
+	runes := make([]int, len(sents))
+	for i, sent := range sents {
+		words[i] = len(strings.Fields(sent.Text))
+		runes[i] = sent.Runes
+		if sent.Confidence >= capitalizedConfidence {
+			stats.ClearBoundaries++
+		} else {
+			stats.InferredBoundaries++
+		}
+	}
+
+	stats.MeanWords, stats.MedianWords, stats.MaxWords = summarize(words)
+	stats.MeanRunes, stats.MedianRunes, stats.MaxRunes = summarize(runes)
+	return stats
+}
+
+// summarize returns the mean, median, and maximum of the non-empty slice xs.
+func summarize(xs []int) (float64, float64, int) {
+	sorted := append([]int{}, xs...)
+	sort.Ints(sorted)
+
+	sum := 0
+	for _, x := range sorted {
+		sum += x
+	}
+	mean := float64(sum) / float64(len(sorted))
+
+	mid := len(sorted) / 2
+	median := float64(sorted[mid])
+	if len(sorted)%2 == 0 {
+		median = float64(sorted[mid-1]+sorted[mid]) / 2
+	}
+
+	return mean, median, sorted[len(sorted)-1]
+}
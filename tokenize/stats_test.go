@@ -0,0 +1,30 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPragmaticStats(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	// Words: 3, 1, 4, 2; runes: 12, 3, 17, 7. Only the boundary before "it"
+	// isn't followed by a capital letter (or the end of the text).
+	text := "I went home. Hi. it was very late.\nThe end"
+	assert.Equal(t, SegmentStats{
+		Sentences:          4,
+		MeanWords:          2.5,
+		MedianWords:        2.5,
+		MaxWords:           4,
+		MeanRunes:          9.75,
+		MedianRunes:        9.5,
+		MaxRunes:           17,
+		ClearBoundaries:    3,
+		InferredBoundaries: 1,
+	}, tok.Stats(text))
+
+	assert.Equal(t, SegmentStats{}, tok.Stats(""))
+}
@@ -0,0 +1,55 @@
+package tokenize
+
+import (
+	"bufio"
+	"io"
+)
+
+// streamChunkSize is the number of bytes that SegmentStream reads at a time.
+const streamChunkSize = 4096
+
+// SegmentStream reads text from r, segments it according to lang (see
+// NewPragmaticSegmenter), and writes each sentence to w on its own line.
+//
+// The input is fed to an Incremental in chunks, so sentences are written as
+// soon as they're complete and large inputs don't need to be segmented all at
+// once. An error reported by the Incremental's Err is returned after all of
+// the sentences have been written.
+func SegmentStream(r io.Reader, w io.Writer, lang string) error {
+	seg, err := NewPragmaticSegmenter(lang)
+	if err != nil {
+		return err
+	}
+	inc := NewIncremental(seg)
+
+	out := bufio.NewWriter(w)
+	write := func(sents []string) error {
+		for _, sent := range sents {
+			if _, err := out.WriteString(sent + "\n"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, readErr := r.Read(buf)
+		if err := write(inc.Feed(string(buf[:n]))); err != nil {
+			return err
+		}
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			return readErr
+		}
+	}
+
+	if err := write(inc.Flush()); err != nil {
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	return inc.Err()
+}
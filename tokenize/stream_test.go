@@ -0,0 +1,27 @@
+package tokenize
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegmentStream(t *testing.T) {
+	text := "I can see Mt. Fuji from here. It is tall!\n\nWhat is your name? My name is Jonas."
+
+	var out bytes.Buffer
+	assert.NoError(t, SegmentStream(strings.NewReader(text), &out, "en"))
+	assert.Equal(t, "I can see Mt. Fuji from here.\nIt is tall!\n"+
+		"What is your name?\nMy name is Jonas.\n", out.String())
+
+	// Reading one byte at a time produces the same output.
+	var small bytes.Buffer
+	reader := iotest.OneByteReader(strings.NewReader(text))
+	assert.NoError(t, SegmentStream(reader, &small, "en"))
+	assert.Equal(t, out.String(), small.String())
+
+	assert.Error(t, SegmentStream(strings.NewReader(text), &out, "xx"))
+}
@@ -0,0 +1,70 @@
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// An Ambiguity describes a sentence boundary that may be incorrect.
+type Ambiguity struct {
+	// Offset is the byte offset of the boundary in the original text (i.e.,
+	// the end of the sentence that precedes it).
+	Offset int
+	// Reason explains why the boundary is uncertain.
+	Reason string
+	// Confidence is the boundary's score, as reported by Sentences.
+	Confidence float64
+}
+
+// TokenizeStrict is like Tokenize, but it also reports every boundary that
+// looks uncertain: one that isn't marked by terminal punctuation, one that's
+// followed by a lowercase letter, or one that falls directly after an
+// abbreviation (e.g., "etc. Then").
+//
+// This is intended for tools, such as linters, that help authors write text
+// that can be segmented reliably.
+func (p *PragmaticSegmenter) TokenizeStrict(text string) ([]string, []Ambiguity) {
+	abbrs := map[string]bool{}
+	for _, abbr := range p.processor.language().abbreviations()["abbreviations"] {
+		abbrs[strings.TrimSpace(abbr)] = true
+	}
+
+	sents := p.Sentences(text)
+	plain := make([]string, len(sents))
+	ambiguities := []Ambiguity{}
+	for i, sent := range sents {
+		plain[i] = sent.Normalized
+		if i == len(sents)-1 {
+			break
+		}
+		if reason := ambiguousBoundary(sent, sents[i+1], abbrs); reason != "" {
+			ambiguities = append(ambiguities, Ambiguity{
+				Offset: sent.End, Reason: reason, Confidence: sent.Confidence})
+		}
+	}
+
+	return plain, ambiguities
+}
+
+// ambiguousBoundary returns the reason that the boundary between sent and
+// next is uncertain, or "" if it isn't.
+func ambiguousBoundary(sent, next Sentence, abbrs map[string]bool) string {
+	body := strings.TrimRight(sent.Text, `"'”’»)]}`)
+	last, size := utf8.DecodeLastRuneInString(body)
+	first, _ := utf8.DecodeRuneInString(strings.TrimLeft(next.Text, `"'“‘«([{¿¡`))
+
+	words := strings.Fields(body[:len(body)-size])
+	switch {
+	case !strings.ContainsRune(terminalPunctuation, last):
+		if strings.Contains(sent.Trailing, "\n") {
+			return "line break without terminal punctuation"
+		}
+		return "no terminal punctuation"
+	case last == '.' && len(words) > 0 && abbrs[strings.ToLower(words[len(words)-1])]:
+		return "abbreviation at the end of a sentence"
+	case !unicode.IsUpper(first):
+		return "sentence starts with a lowercase letter"
+	}
+	return ""
+}
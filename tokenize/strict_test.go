@@ -0,0 +1,32 @@
+package tokenize
+
+import (
+	"testing"
+
+	"github.com/jdkato/prose/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeStrict(t *testing.T) {
+	tok, err := NewPragmaticSegmenter("en")
+	util.CheckError(err)
+
+	text := "We bought apples, pears, etc. Then we went home. It was fine."
+	sents, ambiguities := tok.TokenizeStrict(text)
+	assert.Equal(t, tok.Tokenize(text), sents)
+	assert.Equal(t, []Ambiguity{{
+		Offset:     29,
+		Reason:     "abbreviation at the end of a sentence",
+		Confidence: 0.9,
+	}}, ambiguities)
+	assert.Equal(t, "etc.", text[ambiguities[0].Offset-4:ambiguities[0].Offset])
+
+	_, ambiguities = tok.TokenizeStrict("A heading\nThe body. what now?")
+	assert.Equal(t, []string{
+		"line break without terminal punctuation",
+		"sentence starts with a lowercase letter",
+	}, []string{ambiguities[0].Reason, ambiguities[1].Reason})
+
+	_, ambiguities = tok.TokenizeStrict("This is clear. So is this!")
+	assert.Empty(t, ambiguities)
+}
@@ -0,0 +1,76 @@
+package tokenize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// thaiProcessor segments Thai, which doesn't put spaces between words and
+// rarely uses terminal punctuation. Instead, a space marks the end of a
+// sentence (or clause), so the sentences found by the regexp-based processor
+// are further split at each run of whitespace that separates two Thai words.
+//
+// A space isn't treated as a boundary when it follows a repetition mark
+// ("เด็กๆ ไป") or an abbreviation mark ("กรุงเทพฯ มี"), or when either side
+// is a number or non-Thai text (e.g., "ราคา 100 บาท"), since those spaces are
+// conventionally used within a sentence.
+type thaiProcessor struct {
+	fallback *processor
+}
+
+func (t *thaiProcessor) configure(opts *segmenterOptions) languageProcessor {
+	return t.fallback.configure(opts)
+}
+
+func (t *thaiProcessor) language() languageDefinition {
+	return t.fallback.language()
+}
+
+func (t *thaiProcessor) quoteRegexps() []*regexp.Regexp {
+	return t.fallback.quoteRegexps()
+}
+
+func (t *thaiProcessor) process(text string) ([]string, error) {
+	sents, err := t.fallback.process(text)
+	units := []string{}
+	for _, sent := range sents {
+		units = append(units, splitThai(sent)...)
+	}
+	return units, err
+}
+
+func (t *thaiProcessor) locate(text string, sents []string) [][2]int {
+	return t.fallback.locate(text, sents)
+}
+
+// splitThai splits sent at each space that separates two Thai sentences.
+func splitThai(sent string) []string {
+	units := []string{}
+	start := 0
+	for i := 0; i < len(sent); {
+		r, size := utf8.DecodeRuneInString(sent[i:])
+		if !unicode.IsSpace(r) {
+			i += size
+			continue
+		}
+		end := i + len(sent[i:]) - len(strings.TrimLeftFunc(sent[i:], unicode.IsSpace))
+		prev, _ := utf8.DecodeLastRuneInString(sent[:i])
+		next, _ := utf8.DecodeRuneInString(sent[end:])
+		if endsThaiSentence(prev) && unicode.Is(unicode.Thai, next) &&
+			unicode.IsLetter(next) {
+			units = append(units, sent[start:i])
+			start = end
+		}
+		i = end
+	}
+	return append(units, sent[start:])
+}
+
+// endsThaiSentence reports whether r, which precedes a space, can be the
+// last character of a Thai sentence.
+func endsThaiSentence(r rune) bool {
+	return unicode.Is(unicode.Thai, r) && !unicode.IsDigit(r) &&
+		r != 'ๆ' && r != 'ฯ'
+}
@@ -1,8 +1,12 @@
 package tokenize
 
 import (
+	"bytes"
 	"regexp"
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // TreebankWordTokenizer splits a sentence into words.
@@ -10,8 +14,31 @@ import (
 // This implementation is a port of the Sed script written by Robert McIntyre,
 // which is available at https://gist.github.com/jdkato/fc8b8c4266dba22d45ac85042ae53b1e.
 type TreebankWordTokenizer struct {
+	// FoldDiacritics removes the diacritics from Latin-script tokens (e.g.,
+	// "résumé" -> "resume"), which is useful for search indexing. Tokens in
+	// other scripts are left unchanged.
+	FoldDiacritics bool
+	// Contractions controls how contractions such as "don't" are split
+	// (default: ContractionTreebank).
+	Contractions ContractionStyle
 }
 
+// A ContractionStyle is a way of tokenizing contractions.
+type ContractionStyle int
+
+// The styles supported by TreebankWordTokenizer.
+const (
+	// ContractionTreebank splits off the clitic as in the Penn Treebank:
+	// "don't" -> [do n't] and "they're" -> [they 're]. Words such as
+	// "cannot" and "gonna" are also split ([can not] and [gon na]).
+	ContractionTreebank ContractionStyle = iota
+	// ContractionNone keeps each contraction as a single token: "don't" ->
+	// [don't].
+	ContractionNone
+	// ContractionSplit splits at the apostrophe: "don't" -> [don 't].
+	ContractionSplit
+)
+
 // NewTreebankWordTokenizer is a TreebankWordTokenizer constructor.
 func NewTreebankWordTokenizer() *TreebankWordTokenizer {
 	return new(TreebankWordTokenizer)
@@ -47,6 +74,9 @@ var endingQuotes2 = []*regexp.Regexp{
 	regexp.MustCompile(`([^' ])('[sS]|'[mM]|'[dD]|') `),
 	regexp.MustCompile(`([^' ])('ll|'LL|'re|'RE|'ve|'VE|n't|N'T) `),
 }
+var apostropheSplit = regexp.MustCompile(
+	`([^' ])('[sS]|'[mM]|'[dD]|'ll|'LL|'re|'RE|'ve|'VE|'[tT]|') `)
+var closingSingleQuote = regexp.MustCompile(`([^' ])(') `)
 var contractions = []*regexp.Regexp{
 	regexp.MustCompile(`(?i)\b(can)(not)\b`),
 	regexp.MustCompile(`(?i)\b(d)('ye)\b`),
@@ -65,7 +95,7 @@ var spaces = regexp.MustCompile(`(?: {2,})`)
 // Tokenize splits a sentence into a slice of words.
 //
 // This tokenizer performs the following steps: (1) split on contractions (e.g.,
-// "don't" -> [do n't]), (2) split on non-terminating punctuation, (3) split on
+// "don't" -> [do n't], depending on Contractions), (2) split on non-terminating punctuation, (3) split on
 // single quotes when followed by whitespace, and (4) split on periods that
 // appear at the end of lines.
 //
@@ -98,15 +128,50 @@ func (t TreebankWordTokenizer) Tokenize(text string) []string {
 		text = r.ReplaceAllString(text, substitution)
 	}
 
-	for _, r := range endingQuotes2 {
-		text = r.ReplaceAllString(text, "$1 $2 ")
-	}
-
-	for _, r := range contractions {
-		text = r.ReplaceAllString(text, " $1 $2 ")
+	switch t.Contractions {
+	case ContractionNone:
+		text = endingQuotes2[0].ReplaceAllString(text, "$1 $2 ")
+		text = closingSingleQuote.ReplaceAllString(text, "$1 $2 ")
+	case ContractionSplit:
+		text = endingQuotes2[0].ReplaceAllString(text, "$1 $2 ")
+		text = apostropheSplit.ReplaceAllString(text, "$1 $2 ")
+	default:
+		for _, r := range endingQuotes2 {
+			text = r.ReplaceAllString(text, "$1 $2 ")
+		}
+		for _, r := range contractions {
+			text = r.ReplaceAllString(text, " $1 $2 ")
+		}
 	}
 
 	text = newlines.ReplaceAllString(text, " ")
 	text = strings.TrimSpace(spaces.ReplaceAllString(text, " "))
-	return strings.Split(text, " ")
+
+	tokens := strings.Split(text, " ")
+	if t.FoldDiacritics {
+		for i, token := range tokens {
+			tokens[i] = foldDiacritics(token)
+		}
+	}
+	return tokens
+}
+
+// foldDiacritics decomposes token (NFD) and then removes any combining marks
+// attached to a Latin letter.
+func foldDiacritics(token string) string {
+	var buf bytes.Buffer
+
+	latin := false
+	for _, r := range norm.NFD.String(token) {
+		if unicode.Is(unicode.Mn, r) {
+			if latin {
+				continue
+			}
+		} else {
+			latin = unicode.Is(unicode.Latin, r)
+		}
+		buf.WriteRune(r)
+	}
+
+	return norm.NFC.String(buf.String())
 }
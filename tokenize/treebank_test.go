@@ -14,6 +14,40 @@ func TestTreebankWordTokenizer(t *testing.T) {
 	}
 }
 
+func TestTreebankFoldDiacritics(t *testing.T) {
+	word := NewTreebankWordTokenizer()
+	assert.Equal(t, []string{"naïve", "résumé"}, word.Tokenize("naïve résumé"))
+
+	word.FoldDiacritics = true
+	assert.Equal(t, []string{"naive", "resume"}, word.Tokenize("naïve résumé"))
+	assert.Equal(t, []string{"Ελληνικά", "हिन्दी", "한국어", "Ca", "va", "?"},
+		word.Tokenize("Ελληνικά हिन्दी 한국어 Ça va?"))
+}
+
+func TestTreebankContractions(t *testing.T) {
+	text := "They're sure it's fine, but I can't go."
+	word := NewTreebankWordTokenizer()
+	for style, expected := range map[ContractionStyle][]string{
+		ContractionTreebank: {"They", "'re", "sure", "it", "'s", "fine", ",",
+			"but", "I", "ca", "n't", "go", "."},
+		ContractionNone: {"They're", "sure", "it's", "fine", ",", "but", "I",
+			"can't", "go", "."},
+		ContractionSplit: {"They", "'re", "sure", "it", "'s", "fine", ",",
+			"but", "I", "can", "'t", "go", "."},
+	} {
+		word.Contractions = style
+		assert.Equal(t, expected, word.Tokenize(text), "%d", style)
+	}
+
+	// Closing single quotes are split off in every style.
+	for _, style := range []ContractionStyle{
+		ContractionTreebank, ContractionNone, ContractionSplit} {
+		word.Contractions = style
+		assert.Equal(t, []string{"the", "dogs", "'", "bowls"},
+			word.Tokenize("the dogs' bowls"))
+	}
+}
+
 func BenchmarkTreebankWordTokenizer(b *testing.B) {
 	word := NewTreebankWordTokenizer()
 	for n := 0; n < b.N; n++ {